@@ -18,7 +18,10 @@ func main() {
 		log.Fatalf("Failed to load config: %v", err)
 	}
 
-	server := server.NewServer(cfg)
+	server, err := server.NewServer(cfg)
+	if err != nil {
+		log.Fatalf("Failed to create server: %v", err)
+	}
 
 	go func() {
 		log.Printf("Server starting on port %s...", cfg.Port)
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pvdevs/get-starships-stops/internal/config"
+	"github.com/pvdevs/get-starships-stops/internal/service/swapi"
+)
+
+// snapshot records a live SWAPI crawl into a JSON file that can be served
+// offline with STARSHIP_SOURCE=file:///path/to/snapshot.json
+func main() {
+	out := flag.String("out", "starships.json", "Path of the snapshot file to write")
+	timeout := flag.Duration("timeout", time.Minute, "Maximum time allowed for the crawl")
+	flag.Parse()
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	client := swapi.NewClient(swapi.ClientConfig{
+		BaseURL:        cfg.SWAPIURL,
+		MaxRetries:     cfg.SWAPIMaxRetries,
+		RetryBaseDelay: cfg.SWAPIRetryBaseDelay,
+		RetryMaxDelay:  cfg.SWAPIRetryMaxDelay,
+		Concurrency:    cfg.SWAPIConcurrency,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	log.Printf("Crawling starships from %s...", cfg.SWAPIURL)
+	snapshot, err := client.FetchSnapshot(ctx)
+	if err != nil {
+		log.Fatalf("Failed to crawl starships: %v", err)
+	}
+
+	// Write to a temporary file first so a failed run never leaves a truncated snapshot
+	tmp, err := os.CreateTemp(filepath.Dir(*out), ".starships-*.json")
+	if err != nil {
+		log.Fatalf("Failed to create snapshot file: %v", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := swapi.WriteSnapshot(tmp, snapshot); err != nil {
+		tmp.Close()
+		log.Fatalf("Failed to write snapshot: %v", err)
+	}
+	if err := tmp.Close(); err != nil {
+		log.Fatalf("Failed to write snapshot: %v", err)
+	}
+	if err := os.Rename(tmp.Name(), *out); err != nil {
+		log.Fatalf("Failed to save snapshot: %v", err)
+	}
+
+	log.Printf("Recorded %d starships to %s", snapshot.Count, *out)
+}
@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/pvdevs/get-starships-stops/internal/api/models"
+	"github.com/pvdevs/get-starships-stops/internal/parser"
+	"github.com/pvdevs/get-starships-stops/internal/service"
+)
+
+// HandleBatch handles POST /calculate-stops/batch, calculating stops for many distances
+// with a single fleet fetch. Invalid distances are reported inline.
+func (h *StopsHandler) HandleBatch(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		models.WriteError(w, http.StatusMethodNotAllowed, "Only POST method is allowed")
+		return
+	}
+
+	var req models.BatchRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if err := req.Validate(); err != nil {
+		models.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	dwellHours, err := parseDwell(req.Dwell, h.dwellHours)
+	if err != nil {
+		models.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	strategy, err := parseStrategy(req.Strategy, req.Margin)
+	if err != nil {
+		models.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	reserve, err := parseReserve(req.Reserve)
+	if err != nil {
+		models.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	overrides, err := parseOverrides(req.Overrides)
+	if err != nil {
+		models.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Parse every distance, remembering where the valid ones go in the response
+	response := models.BatchResponse{
+		Model:    req.Model,
+		Strategy: strategy.name,
+		Margin:   strategy.margin,
+		Reserve:  toReserve(reserve),
+		Dwell:    dwellHours,
+		Results:  make([]models.BatchResult, len(req.Distances)),
+		Skipped:  []models.SkippedStarship{},
+	}
+	var distances []int64
+	var positions []int
+	for i, input := range req.Distances {
+		response.Results[i].Distance = input
+		distance, err := parser.ParseDistance(input)
+		if err != nil {
+			response.Results[i].Error = err.Error()
+			continue
+		}
+		distances = append(distances, distance)
+		positions = append(positions, i)
+	}
+
+	if len(distances) > 0 {
+		ctx, source := service.WithSourceRecorder(r.Context())
+		batch, err := h.calculatorFor(req.Model).CalculateStopsBatch(ctx, distances, service.Options{DwellHours: dwellHours, Strategy: strategy.strategy, Reserve: reserve, Overrides: overrides})
+		if err != nil {
+			writeCalculationError(w, err)
+			return
+		}
+		response.Source = source.Source()
+
+		skippedEverywhere, skippedAt := splitSkipped(batch)
+		for i, calculation := range batch {
+			results := toResults(calculation)
+			models.SortResults(results, models.OrderAsc)
+			response.Results[positions[i]].Results = results
+			if len(skippedAt[i]) > 0 {
+				response.Results[positions[i]].Skipped = toSkipped(skippedAt[i])
+			}
+		}
+		response.Skipped = toSkipped(skippedEverywhere)
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// splitSkipped separates the starships skipped for the same reason at every distance of a
+// batch, reported once, from those skipped at some distances only, such as by a stop
+// strategy failing for some distances
+func splitSkipped(batch []service.Calculation) ([]service.SkippedStarship, [][]service.SkippedStarship) {
+	type skipKey struct{ name, reason string }
+	counts := make(map[skipKey]int)
+	for _, calculation := range batch {
+		for _, skipped := range calculation.Skipped {
+			counts[skipKey{skipped.Starship.Name, skipped.Reason}]++
+		}
+	}
+
+	var everywhere []service.SkippedStarship
+	skippedAt := make([][]service.SkippedStarship, len(batch))
+	for i, calculation := range batch {
+		for _, skipped := range calculation.Skipped {
+			switch {
+			case counts[skipKey{skipped.Starship.Name, skipped.Reason}] < len(batch):
+				skippedAt[i] = append(skippedAt[i], skipped)
+			case i == 0:
+				everywhere = append(everywhere, skipped)
+			}
+		}
+	}
+	return everywhere, skippedAt
+}
@@ -0,0 +1,160 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/pvdevs/get-starships-stops/internal/api/models"
+	"github.com/pvdevs/get-starships-stops/internal/domain"
+	"github.com/pvdevs/get-starships-stops/internal/service"
+)
+
+// TestHandleBatch verifies the batch calculation endpoint.
+// It tests various scenarios including:
+// - Mixed valid and invalid distances, reported inline in request order
+// - Batches without any valid distance
+// - Empty and oversized batches
+// - Error propagation from the calculator service
+func TestHandleBatch(t *testing.T) {
+	tests := []struct {
+		name           string   // Description of the test case
+		method         string   // HTTP method of the request
+		body           string   // Request body
+		mockError      error    // Mocked error from the calculator
+		expectedStatus int      // Expected HTTP status code
+		expectedErrors []bool   // Whether each batch entry is expected to carry an error
+		expectedInputs []string // Expected distances echoed back, in order
+	}{
+		{
+			name:           "mixed valid and invalid distances",
+			method:         http.MethodPost,
+			body:           `{"distances":["1000000","abc","50000","-1"]}`,
+			expectedStatus: http.StatusOK,
+			expectedErrors: []bool{false, true, false, true},
+			expectedInputs: []string{"1000000", "abc", "50000", "-1"},
+		},
+		{
+			name:           "no valid distance",
+			method:         http.MethodPost,
+			body:           `{"distances":["abc"]}`,
+			mockError:      fmt.Errorf("must not be called"),
+			expectedStatus: http.StatusOK,
+			expectedErrors: []bool{true},
+			expectedInputs: []string{"abc"},
+		},
+		{
+			name:           "empty batch",
+			method:         http.MethodPost,
+			body:           `{"distances":[]}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "oversized batch",
+			method:         http.MethodPost,
+			body:           `{"distances":["1"` + strings.Repeat(`,"1"`, models.MaxBatchDistances) + `]}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "calculator service error",
+			method:         http.MethodPost,
+			body:           `{"distances":["1000000"]}`,
+			mockError:      fmt.Errorf("calculation error"),
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:           "wrong method",
+			method:         http.MethodGet,
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &StopsHandler{
+				calculator: &mockCalculator{
+					stops: map[string]int64{"X-wing": 59, "Y-wing": 74},
+					err:   tt.mockError,
+				},
+			}
+
+			req := httptest.NewRequest(tt.method, "/calculate-stops/batch", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+
+			h.HandleBatch(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.expectedStatus, rec.Code, rec.Body.String())
+			}
+			if rec.Code != http.StatusOK {
+				return
+			}
+
+			var response models.BatchResponse
+			if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if len(response.Results) != len(tt.expectedInputs) {
+				t.Fatalf("expected %d batch results, got %d", len(tt.expectedInputs), len(response.Results))
+			}
+
+			for i, result := range response.Results {
+				if result.Distance != tt.expectedInputs[i] {
+					t.Errorf("entry %d: expected distance %q, got %q", i, tt.expectedInputs[i], result.Distance)
+				}
+				if hasErr := result.Error != ""; hasErr != tt.expectedErrors[i] {
+					t.Errorf("entry %d: expected error %v, got %q", i, tt.expectedErrors[i], result.Error)
+				}
+				if result.Error == "" && len(result.Results) != 2 {
+					t.Errorf("entry %d: expected 2 ship results, got %d", i, len(result.Results))
+				}
+			}
+		})
+	}
+}
+
+// TestHandleBatch_skipped verifies that starships skipped at every distance are reported
+// once, and those skipped at some distances only with the entries of those distances.
+func TestHandleBatch_skipped(t *testing.T) {
+	strategyError := service.SkippedStarship{Starship: domain.Starship{Name: "Y-wing"}, Reason: service.SkipStrategyError, Detail: "too far"}
+	h := &StopsHandler{
+		calculator: &mockCalculator{
+			stops: map[string]int64{"X-wing": 59},
+			skipped: []service.SkippedStarship{
+				{Starship: domain.Starship{Name: "Death Star"}, Reason: service.SkipZeroSpeed},
+			},
+			distanceSkipped: map[int64][]service.SkippedStarship{1000000: {strategyError}},
+		},
+	}
+
+	for _, body := range []string{`{"distances":["1000000","50000"]}`, `{"distances":["50000","1000000"]}`} {
+		req := httptest.NewRequest(http.MethodPost, "/calculate-stops/batch", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		h.HandleBatch(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s: expected status %d, got %d: %s", body, http.StatusOK, rec.Code, rec.Body.String())
+		}
+		var response models.BatchResponse
+		if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+			t.Fatalf("%s: failed to decode response: %v", body, err)
+		}
+		if len(response.Skipped) != 1 || response.Skipped[0].Name != "Death Star" {
+			t.Errorf("%s: expected only the Death Star skipped everywhere, got %+v", body, response.Skipped)
+		}
+		for _, result := range response.Results {
+			if result.Distance == "1000000" {
+				if len(result.Skipped) != 1 || result.Skipped[0].Name != "Y-wing" || result.Skipped[0].Reason != service.SkipStrategyError {
+					t.Errorf("%s: expected the Y-wing skipped at 1000000, got %+v", body, result.Skipped)
+				}
+			} else if len(result.Skipped) != 0 {
+				t.Errorf("%s: expected nothing skipped at %s only, got %+v", body, result.Distance, result.Skipped)
+			}
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"expvar"
+	"fmt"
+
+	"github.com/pvdevs/get-starships-stops/internal/config"
+	"github.com/pvdevs/get-starships-stops/internal/service"
+	"github.com/pvdevs/get-starships-stops/internal/service/provider"
+	"github.com/pvdevs/get-starships-stops/internal/service/swapi"
+)
+
+// NewStarshipClient creates the starship client shared by all handlers: the configured
+// source, with concurrent fetches coalesced and the fleet cached
+func NewStarshipClient(cfg *config.Config) (service.StarshipClient, error) {
+	client, err := provider.NewFromConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("create starship client: %w", err)
+	}
+	publishSourceStats(client)
+
+	cacheConfig := service.CacheConfig{
+		TTL:          cfg.CacheTTL,
+		RefreshAhead: cfg.CacheRefreshAhead,
+	}
+	if cfg.CacheDir != "" {
+		cacheConfig.Store = service.NewFileStore(cfg.CacheDir)
+	}
+	cache := service.NewCachedClient(service.NewCoalescingClient(client), cacheConfig)
+	publishCacheStats(cache)
+
+	return cache, nil
+}
+
+// publishCacheStats exposes the cache counters under /debug/vars
+func publishCacheStats(cache *service.CachedClient) {
+	if expvar.Get("starship_cache") != nil {
+		return
+	}
+	expvar.Publish("starship_cache", expvar.Func(func() any {
+		return cache.Stats()
+	}))
+}
+
+// publishSourceStats exposes the health of the configured source under /debug/vars:
+// the circuit breaker state for a SWAPI client, or per-source health for a fallback chain,
+// carrying the breaker state of every SWAPI client in the chain
+func publishSourceStats(client service.StarshipClient) {
+	switch c := client.(type) {
+	case *swapi.Client:
+		if expvar.Get("swapi_breaker") == nil {
+			expvar.Publish("swapi_breaker", expvar.Func(func() any {
+				return c.BreakerStatus()
+			}))
+		}
+	case *service.FallbackClient:
+		if expvar.Get("starship_sources") == nil {
+			expvar.Publish("starship_sources", expvar.Func(func() any {
+				return c.Health()
+			}))
+		}
+	}
+}
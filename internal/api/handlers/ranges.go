@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/pvdevs/get-starships-stops/internal/api/models"
+	"github.com/pvdevs/get-starships-stops/internal/parser"
+	"github.com/pvdevs/get-starships-stops/internal/service"
+)
+
+// HandleMaxRange handles GET /max-range/{stops}, the longest distance every starship
+// covers with at most that many stops, keeping the reserve query parameter unused
+func (h *StopsHandler) HandleMaxRange(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		models.WriteError(w, http.StatusMethodNotAllowed, "Only GET method is allowed")
+		return
+	}
+
+	pathParts := strings.Split(r.URL.Path, "/")
+
+	// If no stop budget provided, return helpful message
+	if len(pathParts) <= 2 || pathParts[2] == "" {
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(models.HelpResponse{
+			Message: "Please provide the maximum number of stops after /max-range/",
+			Example: "/max-range/3",
+			Usage:   "GET /max-range/{stops}?model={sublight|hyperdrive}&reserve={percent|duration}",
+		})
+		return
+	}
+
+	if len(pathParts) != 3 {
+		models.WriteError(w, http.StatusBadRequest, "Invalid URL format")
+		return
+	}
+
+	// The stop budget follows the same rules as a distance, a non-negative integer
+	stops, err := parser.ParseDistance(pathParts[2])
+	if err != nil {
+		models.WriteError(w, http.StatusBadRequest, fmt.Errorf("stops: %w", err).Error())
+		return
+	}
+
+	model, err := models.ParseModel(r.URL.Query().Get("model"))
+	if err != nil {
+		models.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	reserve, err := parseReserve(r.URL.Query().Get("reserve"))
+	if err != nil {
+		models.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctx, source := service.WithSourceRecorder(r.Context())
+	calculation, err := h.calculatorFor(model).MaxRanges(ctx, stops, service.Options{Reserve: reserve})
+	if err != nil {
+		writeCalculationError(w, err)
+		return
+	}
+
+	results := make([]models.RangeResult, 0, len(calculation.Ranges)+len(calculation.Unbounded)+len(calculation.Skipped))
+	for name, maxRange := range calculation.Ranges {
+		results = append(results, models.RangeResult{
+			Name:        name,
+			Status:      models.StatusReachable,
+			MaxDistance: &maxRange,
+		})
+	}
+	for _, name := range calculation.Unbounded {
+		results = append(results, models.RangeResult{
+			Name:      name,
+			Status:    models.StatusReachable,
+			Unbounded: true,
+		})
+	}
+	for _, skipped := range calculation.Skipped {
+		results = append(results, models.RangeResult{
+			Name:   skipped.Starship.Name,
+			Status: skipStatus(skipped.Reason),
+		})
+	}
+	models.SortRanges(results)
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(models.RangeResponse{
+		Stops:   stops,
+		Model:   model,
+		Reserve: toReserve(reserve),
+		Source:  source.Source(),
+		Results: results,
+		Skipped: toSkipped(calculation.Skipped),
+	})
+}
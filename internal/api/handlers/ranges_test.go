@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/pvdevs/get-starships-stops/internal/api/models"
+	"github.com/pvdevs/get-starships-stops/internal/domain"
+	"github.com/pvdevs/get-starships-stops/internal/service"
+)
+
+// TestHandleMaxRange verifies the HTTP handler logic for maximum range queries.
+// It tests scenarios including:
+// - Results sorted by range, longest first, with skipped ships last
+// - Reserves passed to the calculator and echoed
+// - Invalid stop budgets, models and URL formats
+// - Error propagation from the calculator service
+func TestHandleMaxRange(t *testing.T) {
+	tests := []struct {
+		name           string // Test case description
+		method         string // HTTP method, GET when empty
+		urlPath        string // Requested URL path
+		mockError      error  // Mocked error from the calculator
+		expectedStatus int    // Expected HTTP status code
+		expectedError  string // Expected prefix of the error message
+		expectedNames  []string
+		wantReserve    service.Reserve // Expected reserve passed to the calculator
+	}{
+		{
+			name:           "sorted by range",
+			urlPath:        "/max-range/2",
+			expectedStatus: http.StatusOK,
+			expectedNames:  []string{"Millennium Falcon", "X-wing", "Y-wing", "Death Star"},
+		},
+		{
+			name:           "reserve",
+			urlPath:        "/max-range/2?reserve=1%20day",
+			expectedStatus: http.StatusOK,
+			expectedNames:  []string{"Millennium Falcon", "X-wing", "Y-wing", "Death Star"},
+			wantReserve:    service.Reserve{Hours: 24},
+		},
+		{
+			name:           "invalid reserve",
+			urlPath:        "/max-range/2?reserve=0%25",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "help message",
+			urlPath:        "/max-range/",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid stop budget",
+			urlPath:        "/max-range/-1",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "stops: ",
+		},
+		{
+			name:           "stop budget too large",
+			urlPath:        "/max-range/99999999999999999999",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "stops: ",
+		},
+		{
+			name:           "unknown model",
+			urlPath:        "/max-range/2?model=warp",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid URL format",
+			urlPath:        "/max-range/2/extra",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "calculator service error",
+			urlPath:        "/max-range/2",
+			mockError:      fmt.Errorf("calculation error"),
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:           "method not allowed",
+			method:         http.MethodPost,
+			urlPath:        "/max-range/2",
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calculator := &mockCalculator{
+				ranges: map[string]int64{"X-wing": 50400, "Y-wing": 40320, "Millennium Falcon": 324000},
+				skipped: []service.SkippedStarship{
+					{Starship: domain.Starship{Name: "Death Star"}, Reason: service.SkipZeroSpeed},
+				},
+				err: tt.mockError,
+			}
+			h := &StopsHandler{calculator: calculator}
+
+			method := tt.method
+			if method == "" {
+				method = http.MethodGet
+			}
+			req := httptest.NewRequest(method, tt.urlPath, nil)
+			rec := httptest.NewRecorder()
+			h.HandleMaxRange(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.expectedStatus, rec.Code, rec.Body.String())
+			}
+			if tt.expectedError != "" {
+				var errResp models.ErrorResponse
+				if err := json.NewDecoder(rec.Body).Decode(&errResp); err != nil || !strings.HasPrefix(errResp.Message, tt.expectedError) {
+					t.Errorf("expected an error message starting with %q, got %+v (%v)", tt.expectedError, errResp, err)
+				}
+			}
+			if tt.expectedNames == nil {
+				return
+			}
+
+			var response models.RangeResponse
+			if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			var names []string
+			for _, result := range response.Results {
+				names = append(names, result.Name)
+			}
+			if strings.Join(names, ",") != strings.Join(tt.expectedNames, ",") {
+				t.Errorf("expected results %v, got %v", tt.expectedNames, names)
+			}
+			if response.Stops != 2 || response.Model != models.ModelSublight || len(response.Skipped) != 1 {
+				t.Errorf("expected 2 stops and 1 skipped ship, got %+v", response)
+			}
+			if calculator.opts.Reserve != tt.wantReserve || (response.Reserve != nil) != (tt.wantReserve != service.Reserve{}) {
+				t.Errorf("expected reserve %+v, got %+v echoed as %+v", tt.wantReserve, calculator.opts.Reserve, response.Reserve)
+			}
+		})
+	}
+}
@@ -0,0 +1,183 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/pvdevs/get-starships-stops/internal/api/models"
+	"github.com/pvdevs/get-starships-stops/internal/config"
+	"github.com/pvdevs/get-starships-stops/internal/parser"
+	"github.com/pvdevs/get-starships-stops/internal/service"
+)
+
+// RecommendHandler holds dependencies for the recommendation handler
+type RecommendHandler struct {
+	recommender  service.RecommenderService            // Recommender for the default sublight model
+	recommenders map[string]service.RecommenderService // Recommenders for the other travel models, by model name
+	dwellHours   int64                                 // Default resupply hours per stop
+}
+
+// NewRecommendHandler creates a new handler with required dependencies
+func NewRecommendHandler(client service.StarshipClient, cfg *config.Config) (*RecommendHandler, error) {
+	dwellHours, err := parseDwell(cfg.ResupplyDwell, 0)
+	if err != nil {
+		return nil, fmt.Errorf("RESUPPLY_DWELL: %w", err)
+	}
+	return &RecommendHandler{
+		recommender: service.NewRecommender(client),
+		recommenders: map[string]service.RecommenderService{
+			models.ModelHyperdrive: service.NewHyperdriveRecommender(client),
+		},
+		dwellHours: dwellHours,
+	}, nil
+}
+
+// recommenderFor returns the recommender of a validated travel model
+func (h *RecommendHandler) recommenderFor(model string) service.RecommenderService {
+	if recommender, ok := h.recommenders[model]; ok {
+		return recommender
+	}
+	return h.recommender
+}
+
+// HandleRecommend handles GET /recommend/{distance}, ranking the starships meeting the
+// constraints given as query parameters: passengers, cargo, max_stops, class, limit, dwell, model, strategy, margin and reserve
+func (h *RecommendHandler) HandleRecommend(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		models.WriteError(w, http.StatusMethodNotAllowed, "Only GET method is allowed")
+		return
+	}
+
+	pathParts := strings.Split(r.URL.Path, "/")
+
+	// If no distance provided, return helpful message
+	if len(pathParts) <= 2 || pathParts[2] == "" {
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(models.HelpResponse{
+			Message: "Please provide a distance in MGLT after /recommend/",
+			Example: "/recommend/1000000?passengers=6&max_stops=10",
+			Usage:   "GET /recommend/{distance}?passengers={n}&cargo={tons}&max_stops={n}&class={class}&limit={n}&dwell={duration}&model={sublight|hyperdrive}&strategy={name}&margin={percent}&reserve={percent|duration}",
+		})
+		return
+	}
+
+	if len(pathParts) != 3 {
+		models.WriteError(w, http.StatusBadRequest, "Invalid URL format")
+		return
+	}
+
+	distance, err := parser.ParseDistance(pathParts[2])
+	if err != nil {
+		models.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	query := r.URL.Query()
+	constraints, err := parseConstraints(query)
+	if err != nil {
+		models.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	dwellHours, err := parseDwell(query.Get("dwell"), h.dwellHours)
+	if err != nil {
+		models.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	model, err := models.ParseModel(query.Get("model"))
+	if err != nil {
+		models.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	strategy, err := parseStrategy(query.Get("strategy"), query.Get("margin"))
+	if err != nil {
+		models.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	reserve, err := parseReserve(query.Get("reserve"))
+	if err != nil {
+		models.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctx, source := service.WithSourceRecorder(r.Context())
+	opts := service.Options{DwellHours: dwellHours, Strategy: strategy.strategy, Reserve: reserve}
+	recommendations, err := h.recommenderFor(model).Recommend(ctx, distance, constraints, opts)
+	if err != nil {
+		writeCalculationError(w, err)
+		return
+	}
+
+	response := models.RecommendResponse{
+		Distance:        distance,
+		Model:           model,
+		Strategy:        strategy.name,
+		Margin:          strategy.margin,
+		Reserve:         toReserve(reserve),
+		Dwell:           dwellHours,
+		Source:          source.Source(),
+		Recommendations: make([]models.Recommendation, 0, len(recommendations)),
+	}
+	for i, recommendation := range recommendations {
+		result := models.Recommendation{
+			Rank:     i + 1,
+			Starship: toStarship(recommendation.Starship),
+			Stops:    recommendation.Stops,
+		}
+		if recommendation.Travel != nil {
+			result.Travel = toTravel(*recommendation.Travel)
+		} else if recommendation.TravelError != nil {
+			result.TravelError = recommendation.TravelError.Error()
+		}
+		response.Recommendations = append(response.Recommendations, result)
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// parseConstraints reads the recommendation constraints from the query parameters
+func parseConstraints(query url.Values) (service.Constraints, error) {
+	var constraints service.Constraints
+	var err error
+
+	if constraints.MinPassengers, err = parseCountParam(query, "passengers"); err != nil {
+		return constraints, err
+	}
+	if constraints.MinCargo, err = parseCountParam(query, "cargo"); err != nil {
+		return constraints, err
+	}
+	if query.Has("max_stops") {
+		maxStops, err := parseCountParam(query, "max_stops")
+		if err != nil {
+			return constraints, err
+		}
+		constraints.MaxStops = &maxStops
+	}
+
+	limit, err := parseCountParam(query, "limit")
+	if err != nil {
+		return constraints, err
+	}
+	if limit > models.MaxRecommendations {
+		return constraints, fmt.Errorf("limit must not be greater than %d, got %d", models.MaxRecommendations, limit)
+	}
+	constraints.Limit = int(limit)
+	constraints.Class = strings.TrimSpace(query.Get("class"))
+	return constraints, nil
+}
+
+// parseCountParam parses an optional non-negative integer query parameter, 0 when absent
+func parseCountParam(query url.Values, name string) (int64, error) {
+	value := query.Get(name)
+	if value == "" {
+		return 0, nil
+	}
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("%s must be a non-negative integer, got %q", name, value)
+	}
+	return n, nil
+}
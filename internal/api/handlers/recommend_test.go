@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pvdevs/get-starships-stops/internal/api/models"
+	"github.com/pvdevs/get-starships-stops/internal/domain"
+	"github.com/pvdevs/get-starships-stops/internal/service"
+)
+
+// mockRecommender implements the recommender interface for testing.
+type mockRecommender struct {
+	constraints service.Constraints // Constraints of the last recommendation
+	opts        service.Options     // Options of the last recommendation
+	called      bool                // Whether a recommendation was requested
+}
+
+// Recommend records its arguments and recommends a single starship.
+func (m *mockRecommender) Recommend(ctx context.Context, distance int64, constraints service.Constraints, opts service.Options) ([]service.Recommendation, error) {
+	m.constraints, m.opts, m.called = constraints, opts, true
+	return []service.Recommendation{
+		{Starship: domain.Starship{Name: "Millennium Falcon", Class: "Light freighter"}, Stops: 9, Travel: &service.Travel{Hours: 13334, Legs: 10, TotalHours: 13334}},
+	}, nil
+}
+
+// TestHandleRecommend verifies the HTTP handler logic for fleet recommendations.
+// It tests scenarios including:
+// - Constraints read from the query parameters
+// - The recommender of the requested travel model
+// - Invalid constraints, distances and URL formats
+func TestHandleRecommend(t *testing.T) {
+	tests := []struct {
+		name           string              // Test case description
+		urlPath        string              // Requested URL, with query parameters
+		expectedStatus int                 // Expected HTTP status code
+		wantCons       service.Constraints // Expected constraints passed to the recommender
+		wantDwell      int64               // Expected resupply hours per stop
+		wantModel      string              // Expected travel model, sublight when empty
+		wantReserve    service.Reserve     // Expected reserve passed to the recommender
+	}{
+		{
+			name:           "no constraints",
+			urlPath:        "/recommend/1000000",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "every constraint",
+			urlPath:        "/recommend/1000000?passengers=6&cargo=1000&class=Light%20freighter&limit=3&dwell=1%20day",
+			expectedStatus: http.StatusOK,
+			wantCons:       service.Constraints{MinPassengers: 6, MinCargo: 1000, Class: "Light freighter", Limit: 3},
+			wantDwell:      24,
+		},
+		{
+			name:           "hyperdrive model",
+			urlPath:        "/recommend/1000000?model=hyperdrive",
+			expectedStatus: http.StatusOK,
+			wantModel:      models.ModelHyperdrive,
+		},
+		{
+			name:           "reserve",
+			urlPath:        "/recommend/1000000?reserve=15%25",
+			expectedStatus: http.StatusOK,
+			wantReserve:    service.Reserve{Percent: 15},
+		},
+		{
+			name:           "invalid reserve",
+			urlPath:        "/recommend/1000000?reserve=always",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid model",
+			urlPath:        "/recommend/1000000?model=warp",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "negative passengers",
+			urlPath:        "/recommend/1000000?passengers=-1",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "unknown strategy",
+			urlPath:        "/recommend/1000000?strategy=guess",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "limit too large",
+			urlPath:        "/recommend/1000000?limit=500",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid distance",
+			urlPath:        "/recommend/far",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid URL format",
+			urlPath:        "/recommend/1000000/extra",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sublight, hyperdrive := &mockRecommender{}, &mockRecommender{}
+			h := &RecommendHandler{
+				recommender:  sublight,
+				recommenders: map[string]service.RecommenderService{models.ModelHyperdrive: hyperdrive},
+			}
+			wantModel, recommender := models.ModelSublight, sublight
+			if tt.wantModel == models.ModelHyperdrive {
+				wantModel, recommender = models.ModelHyperdrive, hyperdrive
+			}
+
+			req := httptest.NewRequest(http.MethodGet, tt.urlPath, nil)
+			rec := httptest.NewRecorder()
+			h.HandleRecommend(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.expectedStatus, rec.Code, rec.Body.String())
+			}
+			if rec.Code != http.StatusOK {
+				return
+			}
+
+			var response models.RecommendResponse
+			if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if response.Model != wantModel || !recommender.called {
+				t.Errorf("expected the %s recommender to answer, got model %q", wantModel, response.Model)
+			}
+			if len(response.Recommendations) != 1 || response.Recommendations[0].Rank != 1 || response.Recommendations[0].Travel == nil {
+				t.Errorf("unexpected recommendations %+v", response.Recommendations)
+			}
+			if recommender.constraints != tt.wantCons || recommender.opts.DwellHours != tt.wantDwell {
+				t.Errorf("expected constraints %+v and dwell %d, got %+v and %d", tt.wantCons, tt.wantDwell, recommender.constraints, recommender.opts.DwellHours)
+			}
+			if recommender.opts.Reserve != tt.wantReserve || (response.Reserve != nil) != (tt.wantReserve != service.Reserve{}) {
+				t.Errorf("expected reserve %+v, got %+v echoed as %+v", tt.wantReserve, recommender.opts.Reserve, response.Reserve)
+			}
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/pvdevs/get-starships-stops/internal/api/models"
+	"github.com/pvdevs/get-starships-stops/internal/parser"
+	"github.com/pvdevs/get-starships-stops/internal/service"
+)
+
+const (
+	maxRequestBodySize = 64 << 10 // Largest JSON request body accepted, in bytes
+)
+
+// decodeJSON strictly decodes a JSON request body into dst: the content type must be
+// JSON, the body must fit in maxRequestBodySize, and unknown fields are rejected.
+// On failure it writes the error response and returns false.
+func decodeJSON(w http.ResponseWriter, r *http.Request, dst any) bool {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || mediaType != "application/json" {
+		models.WriteError(w, http.StatusUnsupportedMediaType, "Content-Type must be application/json")
+		return false
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxRequestBodySize)
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(dst); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		switch {
+		case errors.As(err, &maxBytesErr):
+			models.WriteError(w, http.StatusRequestEntityTooLarge, fmt.Sprintf("Request body must not exceed %d bytes", maxRequestBodySize))
+		case errors.Is(err, io.EOF):
+			models.WriteError(w, http.StatusBadRequest, "Request body must not be empty")
+		default:
+			models.WriteError(w, http.StatusBadRequest, fmt.Sprintf("Invalid request body: %v", err))
+		}
+		return false
+	}
+
+	if err := decoder.Decode(&struct{}{}); !errors.Is(err, io.EOF) {
+		models.WriteError(w, http.StatusBadRequest, "Request body must contain a single JSON object")
+		return false
+	}
+	return true
+}
+
+// parseDwell converts a resupply time per stop such as "2 days" into hours,
+// returning fallback when input is empty. Unlike consumables, a dwell may be zero,
+// written "0" or with a unit such as "0 days", to override a configured default.
+func parseDwell(input string, fallback int64) (int64, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return fallback, nil
+	}
+
+	if number, unit, hasUnit := strings.Cut(input, " "); number == "0" {
+		if hasUnit {
+			if _, err := parser.ParseConsumables("1 " + unit); err != nil {
+				return 0, fmt.Errorf("dwell must be a duration such as \"2 days\": %w", err)
+			}
+		}
+		return 0, nil
+	}
+
+	hours, err := parser.ParseConsumables(input)
+	if err != nil {
+		return 0, fmt.Errorf("dwell must be a duration such as \"2 days\": %w", err)
+	}
+	return hours, nil
+}
+
+// strategyChoice is the stop strategy selected by a request, with the settings its response echoes
+type strategyChoice struct {
+	name     string               // Registered strategy name
+	margin   *int64               // Safety margin percentage, only set for the margin strategy
+	strategy service.StopStrategy // Strategy passed to the calculator
+}
+
+// parseStrategy looks up a registered stop strategy, the exact strategy when name is empty.
+// The margin strategy keeps the safety margin percentage given as margin, such as "15" or
+// "15%", and service.DefaultMarginPercent when margin is empty.
+func parseStrategy(name, margin string) (strategyChoice, error) {
+	name = cmp.Or(name, service.StrategyExact)
+	strategy, err := service.LookupStrategy(name)
+	if err != nil {
+		return strategyChoice{}, fmt.Errorf("strategy: %w", err)
+	}
+
+	margin = strings.TrimSpace(margin)
+	if name != service.StrategyMargin {
+		if margin != "" {
+			return strategyChoice{}, fmt.Errorf("margin only applies to the %q strategy, got strategy %q", service.StrategyMargin, name)
+		}
+		return strategyChoice{name: name, strategy: strategy}, nil
+	}
+
+	percent := int64(service.DefaultMarginPercent)
+	if margin != "" {
+		percent, err = strconv.ParseInt(strings.TrimSpace(strings.TrimSuffix(margin, "%")), 10, 64)
+		if err != nil || percent < 0 || percent > 99 {
+			return strategyChoice{}, fmt.Errorf("margin must be a percentage between 0 and 99, got %q", margin)
+		}
+	}
+	return strategyChoice{name: name, margin: &percent, strategy: service.MarginStrategy{Percent: percent}}, nil
+}
+
+// parseReserve converts a consumables reserve, either a percentage of the autonomy such
+// as "15%" or a duration such as "1 week", returning no reserve when input is empty
+func parseReserve(input string) (service.Reserve, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return service.Reserve{}, nil
+	}
+
+	if number, isPercent := strings.CutSuffix(input, "%"); isPercent {
+		percent, err := strconv.ParseInt(strings.TrimSpace(number), 10, 64)
+		if err != nil || percent <= 0 || percent >= 100 {
+			return service.Reserve{}, fmt.Errorf("reserve percentage must be between 1%% and 99%%, got %q", input)
+		}
+		return service.Reserve{Percent: percent}, nil
+	}
+
+	hours, err := parser.ParseConsumables(input)
+	if err != nil {
+		return service.Reserve{}, fmt.Errorf("reserve must be a percentage such as \"15%%\" or a duration such as \"1 week\": %w", err)
+	}
+	return service.Reserve{Hours: hours}, nil
+}
+
+// parseOverrides converts validated request overrides to service overrides, checking the
+// replacement consumables
+func parseOverrides(overrides map[string]models.Override) (map[string]service.Override, error) {
+	if len(overrides) == 0 {
+		return nil, nil
+	}
+	parsed := make(map[string]service.Override, len(overrides))
+	for key, override := range overrides {
+		if override.Consumables != nil {
+			if _, err := parser.ParseConsumables(*override.Consumables); err != nil {
+				return nil, fmt.Errorf("overrides[%q].consumables must be a duration such as \"3 months\": %w", key, err)
+			}
+		}
+		parsed[key] = service.Override{MGLT: override.MGLT, Consumables: override.Consumables}
+	}
+	return parsed, nil
+}
@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"testing"
+)
+
+// TestParseDwell verifies the conversion of a resupply time per stop into hours.
+// It tests scenarios including:
+// - Falling back to the configured dwell when none is given
+// - Zero dwells overriding the configured one, with or without a unit
+// - Invalid durations and units
+func TestParseDwell(t *testing.T) {
+	tests := []struct {
+		name    string // Test case description
+		input   string // Requested dwell
+		want    int64  // Expected hours
+		wantErr bool   // Whether an error is expected
+	}{
+		{name: "empty uses fallback", input: "", want: 48},
+		{name: "duration", input: "1 day", want: 24},
+		{name: "zero", input: "0", want: 0},
+		{name: "zero with unit", input: " 0 days ", want: 0},
+		{name: "zero with unknown unit", input: "0 parsecs", wantErr: true},
+		{name: "negative", input: "-1 days", wantErr: true},
+		{name: "invalid", input: "a while", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseDwell(tt.input, 48)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseDwell(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseDwell(%q) = %d, want %d", tt.input, got, tt.want)
+			}
+		})
+	}
+}
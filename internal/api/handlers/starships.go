@@ -0,0 +1,248 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/pvdevs/get-starships-stops/internal/api/models"
+	"github.com/pvdevs/get-starships-stops/internal/config"
+	"github.com/pvdevs/get-starships-stops/internal/domain"
+	"github.com/pvdevs/get-starships-stops/internal/parser"
+	"github.com/pvdevs/get-starships-stops/internal/service"
+)
+
+// StarshipsHandler holds dependencies for the starship lookup handlers
+type StarshipsHandler struct {
+	fleet      service.FleetService
+	dwellHours int64 // Default resupply hours per stop
+}
+
+// NewStarshipsHandler creates a new handler with required dependencies
+func NewStarshipsHandler(client service.StarshipClient, cfg *config.Config) (*StarshipsHandler, error) {
+	dwellHours, err := parseDwell(cfg.ResupplyDwell, 0)
+	if err != nil {
+		return nil, fmt.Errorf("RESUPPLY_DWELL: %w", err)
+	}
+	return &StarshipsHandler{
+		fleet:      service.NewFleet(client),
+		dwellHours: dwellHours,
+	}, nil
+}
+
+// HandleStarship handles the starship endpoints
+// URL formats: /starships, /starships/{id-or-name} and /starships/{id-or-name}/stops/{distance},
+// the stops taking dwell, model, strategy, margin and reserve query parameters
+func (h *StarshipsHandler) HandleStarship(w http.ResponseWriter, r *http.Request) {
+	// Method validation
+	if r.Method != http.MethodGet {
+		models.WriteError(w, http.StatusMethodNotAllowed, "Only GET method is allowed")
+		return
+	}
+
+	pathParts := strings.Split(r.URL.Path, "/")
+	if len(pathParts) < 3 || (len(pathParts) == 3 && pathParts[2] == "") {
+		h.listStarships(w, r)
+		return
+	}
+	if pathParts[2] == "" {
+		models.WriteError(w, http.StatusBadRequest, "Please provide a starship id or name after /starships/")
+		return
+	}
+
+	var distance, dwellHours int64
+	var model string
+	var strategy strategyChoice
+	var reserve service.Reserve
+	withStops := false
+	switch {
+	case len(pathParts) == 3:
+	case len(pathParts) == 5 && pathParts[3] == "stops":
+		var err error
+		if distance, err = parser.ParseDistance(pathParts[4]); err != nil {
+			models.WriteError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if dwellHours, err = parseDwell(r.URL.Query().Get("dwell"), h.dwellHours); err != nil {
+			models.WriteError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if model, err = models.ParseModel(r.URL.Query().Get("model")); err != nil {
+			models.WriteError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if strategy, err = parseStrategy(r.URL.Query().Get("strategy"), r.URL.Query().Get("margin")); err != nil {
+			models.WriteError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if reserve, err = parseReserve(r.URL.Query().Get("reserve")); err != nil {
+			models.WriteError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		withStops = true
+	default:
+		models.WriteError(w, http.StatusBadRequest, "Invalid URL format")
+		return
+	}
+
+	ctx, source := service.WithSourceRecorder(r.Context())
+	ship, err := h.fleet.Starship(ctx, pathParts[2])
+	if err != nil {
+		writeLookupError(w, err)
+		return
+	}
+
+	if !withStops {
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(models.StarshipResponse{
+			Source:   source.Source(),
+			Starship: toStarship(ship),
+		})
+		return
+	}
+
+	response := models.StarshipStopsResponse{
+		Distance: distance,
+		Model:    model,
+		Strategy: strategy.name,
+		Margin:   strategy.margin,
+		Reserve:  toReserve(reserve),
+		Source:   source.Source(),
+		Starship: toStarship(ship),
+		Dwell:    dwellHours,
+	}
+	stops, travelling, err := starshipStops(ship, model, reserve, strategy.strategy, distance)
+	// The starship was requested, so a reserve it can't keep makes the request pointless
+	if errors.Is(err, service.ErrReserveTooLarge) {
+		writeCalculationError(w, &service.ReserveError{Starships: []string{ship.Name}})
+		return
+	}
+	if err != nil {
+		response.Reason = service.SkipReason(err)
+		response.Status = skipStatus(response.Reason)
+	} else {
+		response.Status = models.StatusReachable
+		response.Stops = &stops
+		if travel, err := service.TravelForShip(travelling, distance, stops, dwellHours, strategy.strategy); err != nil {
+			response.TravelError = err.Error()
+		} else {
+			response.Travel = toTravel(travel)
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// starshipStops counts the stops of a starship with a validated travel model and a reserve,
+// along with the starship converted to the speed and autonomy it travels with
+func starshipStops(ship domain.Starship, model string, reserve service.Reserve, strategy service.StopStrategy, distance int64) (int64, domain.Starship, error) {
+	var err error
+	if reserve != (service.Reserve{}) {
+		if ship, err = service.ReservedStarship(ship, reserve); err != nil {
+			return 0, ship, err
+		}
+	}
+	if model == models.ModelHyperdrive {
+		if ship, err = service.HyperspaceStarship(ship); err != nil {
+			return 0, ship, err
+		}
+	}
+	stops, err := strategy.Stops(ship, distance)
+	return stops, ship, err
+}
+
+// listStarships writes every starship with its parsed autonomy, and the skipped ones
+func (h *StarshipsHandler) listStarships(w http.ResponseWriter, r *http.Request) {
+	ctx, source := service.WithSourceRecorder(r.Context())
+	audit, err := h.fleet.Audit(ctx)
+	if err != nil {
+		writeCalculationError(w, err)
+		return
+	}
+
+	response := models.StarshipsResponse{
+		Source:    source.Source(),
+		Starships: make([]models.StarshipCapability, 0, len(audit.Starships)),
+	}
+	for _, c := range audit.Starships {
+		response.Starships = append(response.Starships, models.StarshipCapability{
+			Starship:      toStarship(c.Starship),
+			AutonomyHours: c.AutonomyHours,
+			MaxRange:      c.MaxRange,
+		})
+	}
+	response.Skipped = toSkipped(audit.Skipped)
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// toStarship converts a domain starship to its API representation
+func toStarship(ship domain.Starship) models.Starship {
+	return models.Starship{
+		ID:                   ship.ID,
+		Name:                 ship.Name,
+		MGLT:                 toMGLT(ship),
+		Consumables:          ship.Consumables,
+		Model:                ship.Model,
+		Manufacturers:        ship.Manufacturers,
+		Class:                ship.Class,
+		Cost:                 toQuantity(ship.Cost),
+		Length:               toMeasure(ship.Length),
+		MaxAtmospheringSpeed: toMeasure(ship.MaxAtmospheringSpeed),
+		HyperdriveRating:     toMeasure(ship.HyperdriveRating),
+		Crew:                 toQuantity(ship.Crew),
+		Passengers:           toQuantity(ship.Passengers),
+		CargoCapacity:        toQuantity(ship.CargoCapacity),
+	}
+}
+
+// toMGLT returns the MGLT of a starship, nil when unknown
+func toMGLT(ship domain.Starship) *int {
+	if ship.UnknownMGLT {
+		return nil
+	}
+	return &ship.MGLT
+}
+
+// toQuantity converts a domain quantity to its API representation, nil when unknown
+func toQuantity(q domain.Quantity) *models.Quantity {
+	if !q.Known {
+		return nil
+	}
+	return &models.Quantity{Min: q.Min, Max: q.Max}
+}
+
+// toMeasure converts a domain measure to its API representation, nil when unknown
+func toMeasure(m domain.Measure) *float64 {
+	if !m.Known {
+		return nil
+	}
+	return &m.Value
+}
+
+// toSkipped converts the starships skipped by the calculator to their API representation
+func toSkipped(skipped []service.SkippedStarship) []models.SkippedStarship {
+	ships := make([]models.SkippedStarship, 0, len(skipped))
+	for _, s := range skipped {
+		ships = append(ships, models.SkippedStarship{
+			Starship: toStarship(s.Starship),
+			Reason:   s.Reason,
+			Detail:   s.Detail,
+		})
+	}
+	return ships
+}
+
+// writeLookupError maps an error from a starship lookup to an error response
+func writeLookupError(w http.ResponseWriter, err error) {
+	var notFound *service.NotFoundError
+	if errors.As(err, &notFound) {
+		models.WriteNotFound(w, fmt.Sprintf("Starship %q not found", notFound.Key), notFound.Suggestions)
+		return
+	}
+	writeCalculationError(w, err)
+}
@@ -0,0 +1,315 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/pvdevs/get-starships-stops/internal/api/models"
+	"github.com/pvdevs/get-starships-stops/internal/domain"
+	"github.com/pvdevs/get-starships-stops/internal/service"
+)
+
+// mockFleet implements the fleet interface for testing.
+type mockFleet struct {
+	starships []domain.Starship // Mocked fleet to look starships up in
+	err       error             // Mocked error for testing
+}
+
+// Starship simulates a lookup in the fleet.
+func (m *mockFleet) Starship(ctx context.Context, key string) (domain.Starship, error) {
+	if m.err != nil {
+		return domain.Starship{}, m.err
+	}
+	return service.FindStarship(m.starships, key)
+}
+
+// Audit simulates auditing the fleet.
+func (m *mockFleet) Audit(ctx context.Context) (service.FleetAudit, error) {
+	if m.err != nil {
+		return service.FleetAudit{}, m.err
+	}
+	return service.AuditStarships(m.starships), nil
+}
+
+// TestHandleStarship verifies the HTTP handler logic for single starship lookups.
+// It tests scenarios including:
+// - Looking a starship up, with and without its stops for a distance
+// - Unknown starships answering 404 with suggestions
+// - Invalid URLs, distances and starship data
+// - Error propagation from the fleet service
+func TestHandleStarship(t *testing.T) {
+	fleet := []domain.Starship{
+		{ID: "10", Name: "Millennium Falcon", MGLT: 75, Consumables: "2 months", HyperdriveRating: domain.Measure{Value: 0.5, Known: true}},
+		{ID: "12", Name: "X-wing", MGLT: 100, Consumables: "1 week"},
+		{ID: "99", Name: "Broken", MGLT: 10, Consumables: "forever"},
+		{ID: "9", Name: "Death Star", MGLT: 0, Consumables: "3 years"},
+		{ID: "50", Name: "Slow", MGLT: 1, Consumables: "1 day"},
+	}
+
+	tests := []struct {
+		name            string   // Test case description
+		method          string   // HTTP method, GET when empty
+		urlPath         string   // Requested URL path
+		mockError       error    // Mocked error from the fleet service
+		expectedStatus  int      // Expected HTTP status code
+		wantName        string   // Expected starship name on success
+		wantStatus      string   // Expected result status, when a distance is given
+		wantStops       int64    // Expected stops, when the status is reachable
+		wantLegHours    int64    // Expected hours per leg, checked when positive
+		wantTravelError bool     // Whether the travel is expected to be replaced by its error
+		wantSuggestions []string // Expected suggestions on 404
+	}{
+		{
+			name:           "starship by id",
+			urlPath:        "/starships/12",
+			expectedStatus: http.StatusOK,
+			wantName:       "X-wing",
+		},
+		{
+			name:           "stops by name",
+			urlPath:        "/starships/millennium%20falcon/stops/1000000",
+			expectedStatus: http.StatusOK,
+			wantName:       "Millennium Falcon",
+			wantStatus:     models.StatusReachable,
+			wantStops:      9,
+		},
+		{
+			name:           "stops with a strategy",
+			urlPath:        "/starships/12/stops/16800?strategy=ceiling",
+			expectedStatus: http.StatusOK,
+			wantName:       "X-wing",
+			wantStatus:     models.StatusReachable,
+			wantStops:      1,
+			wantLegHours:   167,
+		},
+		{
+			name:           "stops with the margin strategy",
+			urlPath:        "/starships/10/stops/1000000?strategy=margin&margin=50",
+			expectedStatus: http.StatusOK,
+			wantName:       "Millennium Falcon",
+			wantStatus:     models.StatusReachable,
+			wantStops:      18,
+			wantLegHours:   720,
+		},
+		{
+			name:           "stops with a margin percentage",
+			urlPath:        "/starships/12/stops/16800?strategy=margin&margin=0",
+			expectedStatus: http.StatusOK,
+			wantName:       "X-wing",
+			wantStatus:     models.StatusReachable,
+			wantStops:      0,
+		},
+		{
+			name:           "stops with a reserve",
+			urlPath:        "/starships/12/stops/16800?reserve=1%20day",
+			expectedStatus: http.StatusOK,
+			wantName:       "X-wing",
+			wantStatus:     models.StatusReachable,
+			wantStops:      1,
+			wantLegHours:   144,
+		},
+		{
+			name:           "reserve exceeding the autonomy",
+			urlPath:        "/starships/12/stops/16800?reserve=1%20week",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid reserve",
+			urlPath:        "/starships/12/stops/16800?reserve=100%25",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "stops with the hyperdrive model",
+			urlPath:        "/starships/10/stops/1000000?model=hyperdrive",
+			expectedStatus: http.StatusOK,
+			wantName:       "Millennium Falcon",
+			wantStatus:     models.StatusReachable,
+			wantStops:      4,
+		},
+		{
+			name:           "hyperdrive model without a hyperdrive rating",
+			urlPath:        "/starships/12/stops/1000000?model=hyperdrive",
+			expectedStatus: http.StatusOK,
+			wantName:       "X-wing",
+			wantStatus:     models.StatusUnknown,
+		},
+		{
+			name:           "invalid model",
+			urlPath:        "/starships/12/stops/1000000?model=warp",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "unknown strategy",
+			urlPath:        "/starships/12/stops/16800?strategy=guess",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:            "unknown starship",
+			urlPath:         "/starships/X-wnig/stops/1000000",
+			expectedStatus:  http.StatusNotFound,
+			wantSuggestions: []string{"X-wing"},
+		},
+		{
+			name:           "invalid distance",
+			urlPath:        "/starships/12/stops/far",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid URL format",
+			urlPath:        "/starships/12/range/1000",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "missing starship",
+			urlPath:        "/starships//stops/1000",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "unusable consumables",
+			urlPath:        "/starships/99/stops/1000",
+			expectedStatus: http.StatusOK,
+			wantName:       "Broken",
+			wantStatus:     models.StatusUnknown,
+		},
+		{
+			name:            "travel too long to represent",
+			urlPath:         "/starships/slow/stops/9223372036854775807?dwell=1%20year",
+			expectedStatus:  http.StatusOK,
+			wantName:        "Slow",
+			wantStatus:      models.StatusReachable,
+			wantStops:       384307168202282325,
+			wantTravelError: true,
+		},
+		{
+			name:           "zero speed",
+			urlPath:        "/starships/death%20star/stops/1000",
+			expectedStatus: http.StatusOK,
+			wantName:       "Death Star",
+			wantStatus:     models.StatusUnreachable,
+		},
+		{
+			name:           "fleet service error",
+			urlPath:        "/starships/12",
+			mockError:      fmt.Errorf("fetch starships: upstream down"),
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:           "method not allowed",
+			method:         http.MethodPost,
+			urlPath:        "/starships/12",
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &StarshipsHandler{fleet: &mockFleet{starships: fleet, err: tt.mockError}}
+
+			method := tt.method
+			if method == "" {
+				method = http.MethodGet
+			}
+			req := httptest.NewRequest(method, tt.urlPath, nil)
+			rr := httptest.NewRecorder()
+			handler.HandleStarship(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.expectedStatus, rr.Code, rr.Body.String())
+			}
+
+			switch rr.Code {
+			case http.StatusOK:
+				var response models.StarshipStopsResponse
+				if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				if response.Starship.Name != tt.wantName {
+					t.Errorf("expected starship %s, got %s", tt.wantName, response.Starship.Name)
+				}
+				if response.Status != tt.wantStatus {
+					t.Errorf("expected status %q, got %q", tt.wantStatus, response.Status)
+				}
+				if tt.wantStatus == models.StatusReachable && (response.Stops == nil || *response.Stops != tt.wantStops) {
+					t.Errorf("expected %d stops, got %v", tt.wantStops, response.Stops)
+				} else if tt.wantStatus != models.StatusReachable && response.Stops != nil {
+					t.Errorf("expected no stops, got %d", *response.Stops)
+				}
+				if tt.wantLegHours > 0 && (response.Travel == nil || response.Travel.LegHours != tt.wantLegHours) {
+					t.Errorf("expected legs of %d hours, got %+v", tt.wantLegHours, response.Travel)
+				}
+				if tt.wantTravelError != (response.TravelError != "") || (tt.wantTravelError && response.Travel != nil) {
+					t.Errorf("expected a travel error %v, got travel %+v and error %q", tt.wantTravelError, response.Travel, response.TravelError)
+				}
+			case http.StatusNotFound:
+				var response models.ErrorResponse
+				if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				if fmt.Sprint(response.Suggestions) != fmt.Sprint(tt.wantSuggestions) {
+					t.Errorf("expected suggestions %v, got %v", tt.wantSuggestions, response.Suggestions)
+				}
+			}
+		})
+	}
+}
+
+// TestHandleStarship_list verifies listing every starship with its parsed autonomy,
+// a null range when it overflows, and the starships skipped because of unusable data
+// without an MGLT they don't have.
+func TestHandleStarship_list(t *testing.T) {
+	handler := &StarshipsHandler{fleet: &mockFleet{starships: []domain.Starship{
+		{ID: "12", Name: "X-wing", MGLT: 100, Consumables: "1 week"},
+		{ID: "99", Name: "Broken", MGLT: 10, Consumables: "forever"},
+		{ID: "10", Name: "Millennium Falcon", MGLT: 75, Consumables: "2 months"},
+		{ID: "77", Name: "Eternal", MGLT: 10000, Consumables: "1000000000000 years"},
+		{ID: "15", Name: "Executor", UnknownMGLT: true, Consumables: "6 years"},
+	}}}
+
+	for _, urlPath := range []string{"/starships", "/starships/"} {
+		req := httptest.NewRequest(http.MethodGet, urlPath, nil)
+		rr := httptest.NewRecorder()
+		handler.HandleStarship(rr, req)
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("%s: expected status %d, got %d", urlPath, http.StatusOK, rr.Code)
+		}
+		var response models.StarshipsResponse
+		if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+
+		want := []models.StarshipCapability{
+			{Starship: models.Starship{ID: "77", Name: "Eternal", MGLT: intPtr(10000), Consumables: "1000000000000 years"}, AutonomyHours: 8760000000000000},
+			{Starship: models.Starship{ID: "10", Name: "Millennium Falcon", MGLT: intPtr(75), Consumables: "2 months"}, AutonomyHours: 1440, MaxRange: int64Ptr(108000)},
+			{Starship: models.Starship{ID: "12", Name: "X-wing", MGLT: intPtr(100), Consumables: "1 week"}, AutonomyHours: 168, MaxRange: int64Ptr(16800)},
+		}
+		if !reflect.DeepEqual(response.Starships, want) {
+			t.Errorf("%s: expected starships %+v, got %+v", urlPath, want, response.Starships)
+		}
+		wantSkipped := []models.SkippedStarship{
+			{Starship: models.Starship{ID: "99", Name: "Broken", MGLT: intPtr(10), Consumables: "forever"}, Reason: service.SkipInvalidConsumables},
+			{Starship: models.Starship{ID: "15", Name: "Executor", Consumables: "6 years"}, Reason: service.SkipUnknownMGLT},
+		}
+		for i := range response.Skipped {
+			response.Skipped[i].Detail = ""
+		}
+		if !reflect.DeepEqual(response.Skipped, wantSkipped) {
+			t.Errorf("%s: expected skipped starships %+v, got %+v", urlPath, wantSkipped, response.Skipped)
+		}
+	}
+}
+
+// intPtr returns a pointer to v.
+func intPtr(v int) *int {
+	return &v
+}
+
+// int64Ptr returns a pointer to v.
+func int64Ptr(v int64) *int64 {
+	return &v
+}
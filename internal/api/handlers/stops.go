@@ -1,9 +1,11 @@
 package handlers
 
 import (
-	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"slices"
 	"strings"
 
 	"github.com/pvdevs/get-starships-stops/internal/api/models"
@@ -15,27 +17,48 @@ import (
 
 // StopsHandler holds dependencies for all handlers
 type StopsHandler struct {
-	calculator service.CalculatorService
+	calculator  service.CalculatorService            // Calculator for the default sublight model
+	calculators map[string]service.CalculatorService // Calculators for the other travel models, by model name
+	dwellHours  int64                                // Default resupply hours per stop
 }
 
 // NewStopsHandler creates a new handler with required dependencies
-func NewStopsHandler(cfg *config.Config) *StopsHandler {
-	client := swapi.NewClient(swapi.ClientConfig{
-		BaseURL: cfg.SWAPIURL,
-	})
+func NewStopsHandler(client service.StarshipClient, cfg *config.Config) (*StopsHandler, error) {
+	dwellHours, err := parseDwell(cfg.ResupplyDwell, 0)
+	if err != nil {
+		return nil, fmt.Errorf("RESUPPLY_DWELL: %w", err)
+	}
 	return &StopsHandler{
 		calculator: service.NewCalculator(client),
+		calculators: map[string]service.CalculatorService{
+			models.ModelHyperdrive: service.NewHyperdriveCalculator(client),
+		},
+		dwellHours: dwellHours,
+	}, nil
+}
+
+// calculatorFor returns the calculator of a validated travel model
+func (h *StopsHandler) calculatorFor(model string) service.CalculatorService {
+	if calculator, ok := h.calculators[model]; ok {
+		return calculator
 	}
+	return h.calculator
 }
 
 // HandleCalculate handles the stop calculation endpoint
 func (h *StopsHandler) HandleCalculate(w http.ResponseWriter, r *http.Request) {
-	// Method validation
-	if r.Method != http.MethodGet {
-		models.WriteError(w, http.StatusMethodNotAllowed, "Only GET method is allowed")
-		return
+	switch r.Method {
+	case http.MethodGet:
+		h.handleCalculatePath(w, r)
+	case http.MethodPost:
+		h.handleCalculateBody(w, r)
+	default:
+		models.WriteError(w, http.StatusMethodNotAllowed, "Only GET and POST methods are allowed")
 	}
+}
 
+// handleCalculatePath handles GET /calculate-stops/{distance}
+func (h *StopsHandler) handleCalculatePath(w http.ResponseWriter, r *http.Request) {
 	// Get distance from path parameter
 	// URL format: /calculate-stops/{distance}
 	pathParts := strings.Split(r.URL.Path, "/")
@@ -46,7 +69,7 @@ func (h *StopsHandler) HandleCalculate(w http.ResponseWriter, r *http.Request) {
 		json.NewEncoder(w).Encode(models.HelpResponse{
 			Message: "Please provide a distance in MGLT after /calculate-stops/",
 			Example: "/calculate-stops/1000000",
-			Usage:   "GET /calculate-stops/{distance}",
+			Usage:   "GET /calculate-stops/{distance} or POST /calculate-stops with a JSON body",
 		})
 		return
 	}
@@ -56,34 +79,204 @@ func (h *StopsHandler) HandleCalculate(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	query := r.URL.Query()
+	req := models.StopsRequest{
+		Distance: pathParts[2],
+		Dwell:    query.Get("dwell"),
+		Model:    query.Get("model"),
+		Strategy: query.Get("strategy"),
+		Margin:   query.Get("margin"),
+		Reserve:  query.Get("reserve"),
+	}
+	if err := req.Validate(); err != nil {
+		models.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	h.calculate(w, r, req)
+}
+
+// handleCalculateBody handles POST /calculate-stops with a JSON models.StopsRequest body
+func (h *StopsHandler) handleCalculateBody(w http.ResponseWriter, r *http.Request) {
+	if path := strings.TrimSuffix(r.URL.Path, "/"); path != "/calculate-stops" {
+		models.WriteError(w, http.StatusBadRequest, "Invalid URL format, POST the request body to /calculate-stops")
+		return
+	}
+
+	var req models.StopsRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if err := req.Validate(); err != nil {
+		models.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	h.calculate(w, r, req)
+}
+
+// calculate runs the stop calculation for a validated request and writes the response
+func (h *StopsHandler) calculate(w http.ResponseWriter, r *http.Request, req models.StopsRequest) {
 	// Parse distance
-	distance, err := parser.ParseDistance(pathParts[2])
+	distance, err := parser.ParseDistance(req.Distance)
 	if err != nil {
 		models.WriteError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	// Use the handler's calculator instance
-	stops, err := h.calculator.CalculateStops(context.Background(), distance)
+	dwellHours, err := parseDwell(req.Dwell, h.dwellHours)
+	if err != nil {
+		models.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	strategy, err := parseStrategy(req.Strategy, req.Margin)
 	if err != nil {
-		models.WriteError(w, http.StatusInternalServerError, "Failed to calculate stops")
+		models.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	reserve, err := parseReserve(req.Reserve)
+	if err != nil {
+		models.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	overrides, err := parseOverrides(req.Overrides)
+	if err != nil {
+		models.WriteError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	// Convert map to slice and sort
-	var results []models.Result
-	for name, numStops := range stops {
-		results = append(results, models.Result{
-			Name:  name,
-			Stops: numStops,
+	// Use the calculator of the requested model
+	ctx, source := service.WithSourceRecorder(r.Context())
+	opts := service.Options{DwellHours: dwellHours, Strategy: strategy.strategy, Reserve: reserve, Overrides: overrides}
+	calculation, err := h.calculatorFor(req.Model).CalculateStops(ctx, distance, opts)
+	if err != nil {
+		writeCalculationError(w, err)
+		return
+	}
+
+	// Convert map to slice, keeping only the requested ships
+	results := toResults(calculation)
+	skipped := toSkipped(calculation.Skipped)
+	if len(req.Ships) > 0 {
+		wanted := make(map[string]bool, len(req.Ships))
+		for _, name := range req.Ships {
+			wanted[strings.ToLower(name)] = true
+		}
+		results = slices.DeleteFunc(results, func(result models.Result) bool {
+			return !wanted[strings.ToLower(result.Name)]
+		})
+		skipped = slices.DeleteFunc(skipped, func(ship models.SkippedStarship) bool {
+			return !wanted[strings.ToLower(ship.Name)]
 		})
+
+		// A requested starship that can't keep the reserve makes the request pointless
+		if err := reserveError(skipped); err != nil {
+			writeCalculationError(w, err)
+			return
+		}
 	}
-	models.SortResults(results)
 
-	// Return success response
-	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(models.StopsResponse{
+	if req.Sort == models.SortByName {
+		models.SortResultsByName(results, req.Order)
+	} else {
+		models.SortResults(results, req.Order)
+	}
+
+	response := models.StopsResponse{
 		Distance: distance,
+		Model:    req.Model,
+		Strategy: strategy.name,
+		Margin:   strategy.margin,
+		Reserve:  toReserve(reserve),
+		Dwell:    dwellHours,
+		Source:   source.Source(),
 		Results:  results,
-	})
+		Skipped:  skipped,
+	}
+	if req.Detail == models.DetailSummary {
+		response.Summary = models.Summarize(results)
+		response.Results = nil
+	}
+
+	// Return success response
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// toResults converts a calculation into a slice of Results with their travel time,
+// and the skipped starships reported as unreachable or unknown
+func toResults(calculation service.Calculation) []models.Result {
+	results := make([]models.Result, 0, len(calculation.Stops)+len(calculation.Skipped))
+	for name, numStops := range calculation.Stops {
+		result := models.Reachable(name, numStops)
+		if travel, ok := calculation.Travel[name]; ok {
+			result.Travel = toTravel(travel)
+		} else if err := calculation.TravelErrors[name]; err != nil {
+			result.TravelError = err.Error()
+		}
+		result.Overridden = calculation.Overridden[name]
+		results = append(results, result)
+	}
+	for _, skipped := range calculation.Skipped {
+		results = append(results, models.Result{
+			Name:       skipped.Starship.Name,
+			Status:     skipStatus(skipped.Reason),
+			Overridden: calculation.Overridden[skipped.Starship.Name],
+		})
+	}
+	return results
+}
+
+// toTravel converts a starship's travel time to its API representation
+func toTravel(travel service.Travel) *models.Travel {
+	return &models.Travel{
+		Hours:      travel.Hours,
+		Legs:       travel.Legs,
+		LegHours:   travel.LegHours,
+		DwellHours: travel.DwellHours,
+		TotalHours: travel.TotalHours,
+		Duration:   parser.FormatHours(travel.TotalHours),
+	}
+}
+
+// toReserve converts a consumables reserve to its API representation, nil when there is none
+func toReserve(reserve service.Reserve) *models.Reserve {
+	if reserve == (service.Reserve{}) {
+		return nil
+	}
+	return &models.Reserve{Percent: reserve.Percent, Hours: reserve.Hours}
+}
+
+// reserveError returns a ReserveError naming the skipped starships whose autonomy doesn't
+// cover the reserve, nil when there are none
+func reserveError(skipped []models.SkippedStarship) error {
+	var names []string
+	for _, ship := range skipped {
+		if ship.Reason == service.SkipReserveTooLarge {
+			names = append(names, ship.Name)
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	return &service.ReserveError{Starships: names}
+}
+
+// skipStatus returns the result status of a starship skipped for the given reason
+func skipStatus(reason string) string {
+	if reason == service.SkipZeroSpeed || reason == service.SkipReserveTooLarge {
+		return models.StatusUnreachable
+	}
+	return models.StatusUnknown
+}
+
+// writeCalculationError maps an error from the calculator to an error response
+func writeCalculationError(w http.ResponseWriter, err error) {
+	if errors.Is(err, service.ErrReserveTooLarge) || errors.Is(err, service.ErrInvalidOverride) {
+		models.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if errors.Is(err, swapi.ErrCircuitOpen) {
+		models.WriteError(w, http.StatusServiceUnavailable, "Starship data is temporarily unavailable because SWAPI is failing, please retry later")
+		return
+	}
+	models.WriteError(w, http.StatusInternalServerError, "Failed to calculate stops")
 }
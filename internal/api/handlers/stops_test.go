@@ -11,20 +11,55 @@ import (
 	"testing"
 
 	"github.com/pvdevs/get-starships-stops/internal/api/models"
+	"github.com/pvdevs/get-starships-stops/internal/domain"
+	"github.com/pvdevs/get-starships-stops/internal/service"
+	"github.com/pvdevs/get-starships-stops/internal/service/swapi"
 )
 
 // mockCalculator implements the calculator interface for testing.
 type mockCalculator struct {
-	stops map[string]int // Mocked stops result for testing
-	err   error          // Mocked error for testing
+	stops   map[string]int64          // Mocked stops result for testing
+	skipped []service.SkippedStarship // Mocked skipped starships for testing
+	ranges  map[string]int64          // Mocked maximum ranges for testing
+	err     error                     // Mocked error for testing
+	opts    service.Options           // Options of the last calculation
+
+	overridden map[string][]string // Mocked overridden values for testing
+
+	distanceSkipped map[int64][]service.SkippedStarship // Mocked skipped starships per batch distance, on top of skipped
+	travelErrors    map[string]error                    // Mocked travel errors per starship name
 }
 
 // CalculateStops simulates the calculation logic of the calculator.
-func (m *mockCalculator) CalculateStops(ctx context.Context, distance int64) (map[string]int, error) {
+func (m *mockCalculator) CalculateStops(ctx context.Context, distance int64, opts service.Options) (service.Calculation, error) {
+	m.opts = opts
+	if m.err != nil {
+		return service.Calculation{}, m.err
+	}
+	return service.Calculation{Stops: m.stops, TravelErrors: m.travelErrors, Skipped: m.skipped, Overridden: m.overridden}, nil
+}
+
+// CalculateStopsBatch simulates a batch calculation, returning the mocked stops for every distance.
+func (m *mockCalculator) CalculateStopsBatch(ctx context.Context, distances []int64, opts service.Options) ([]service.Calculation, error) {
+	m.opts = opts
 	if m.err != nil {
 		return nil, m.err
 	}
-	return m.stops, nil
+	results := make([]service.Calculation, len(distances))
+	for i, distance := range distances {
+		skipped := append(m.skipped[:len(m.skipped):len(m.skipped)], m.distanceSkipped[distance]...)
+		results[i] = service.Calculation{Stops: m.stops, Skipped: skipped, Overridden: m.overridden}
+	}
+	return results, nil
+}
+
+// MaxRanges simulates a maximum range calculation, returning the mocked ranges.
+func (m *mockCalculator) MaxRanges(ctx context.Context, stops int64, opts service.Options) (service.RangeCalculation, error) {
+	m.opts = opts
+	if m.err != nil {
+		return service.RangeCalculation{}, m.err
+	}
+	return service.RangeCalculation{Ranges: m.ranges, Skipped: m.skipped}, nil
 }
 
 // TestCalculateStops verifies the HTTP handler logic for calculating stops.
@@ -32,24 +67,35 @@ func (m *mockCalculator) CalculateStops(ctx context.Context, distance int64) (ma
 // - Valid URL paths with correct distance parameters
 // - Invalid URL formats and missing parameters
 // - Error propagation from the calculator service
+// - Reporting the starships the calculator skipped
 func TestCalculateStops(t *testing.T) {
 	// Define test cases using table-driven test pattern
 	tests := []struct {
-		name           string         // Description of the test case
-		urlPath        string         // URL path including the distance parameter
-		mockStops      map[string]int // Mocked stops data from the calculator
-		mockError      error          // Mocked error from the calculator
-		expectedStatus int            // Expected HTTP status code
+		name           string                    // Description of the test case
+		urlPath        string                    // URL path including the distance parameter
+		mockStops      map[string]int64          // Mocked stops data from the calculator
+		mockSkipped    []service.SkippedStarship // Mocked skipped starships from the calculator
+		mockError      error                     // Mocked error from the calculator
+		expectedStatus int                       // Expected HTTP status code
 	}{
 		{
 			name:    "valid request with proper distance",
 			urlPath: "/calculate-stops/1000000",
-			mockStops: map[string]int{
+			mockStops: map[string]int64{
 				"X-wing": 50,
 				"Y-wing": 74,
 			},
 			expectedStatus: http.StatusOK,
 		},
+		{
+			name:      "skipped ships are reported",
+			urlPath:   "/calculate-stops/1000000",
+			mockStops: map[string]int64{"X-wing": 59},
+			mockSkipped: []service.SkippedStarship{
+				{Starship: domain.Starship{Name: "Executor", UnknownMGLT: true}, Reason: service.SkipUnknownMGLT},
+			},
+			expectedStatus: http.StatusOK,
+		},
 		{
 			name:           "invalid distance format",
 			urlPath:        "/calculate-stops/invalid",
@@ -61,6 +107,12 @@ func TestCalculateStops(t *testing.T) {
 			mockError:      fmt.Errorf("calculation error"),
 			expectedStatus: http.StatusInternalServerError,
 		},
+		{
+			name:           "SWAPI circuit breaker open",
+			urlPath:        "/calculate-stops/1000000",
+			mockError:      fmt.Errorf("fetch starships: %w", swapi.ErrCircuitOpen),
+			expectedStatus: http.StatusServiceUnavailable,
+		},
 		{
 			name:           "malformed URL path",
 			urlPath:        "/calculate-stops/1000000/extra",
@@ -74,8 +126,9 @@ func TestCalculateStops(t *testing.T) {
 			// Create handler with mock calculator
 			h := &StopsHandler{
 				calculator: &mockCalculator{
-					stops: tt.mockStops,
-					err:   tt.mockError,
+					stops:   tt.mockStops,
+					skipped: tt.mockSkipped,
+					err:     tt.mockError,
 				},
 			}
 
@@ -105,21 +158,605 @@ func TestCalculateStops(t *testing.T) {
 					t.Errorf("expected distance %d, got %d", expectedDistance, response.Distance)
 				}
 
-				// Verify results match mock data
-				if len(response.Results) != len(tt.mockStops) {
-					t.Errorf("expected %d results, got %d", len(tt.mockStops), len(response.Results))
+				// Verify results match mock data, skipped ships included
+				if len(response.Results) != len(tt.mockStops)+len(tt.mockSkipped) {
+					t.Errorf("expected %d results, got %d", len(tt.mockStops)+len(tt.mockSkipped), len(response.Results))
 				}
 
 				// Verify each result matches the mock data
-				resultMap := make(map[string]int)
+				resultMap := make(map[string]int64)
 				for _, result := range response.Results {
-					resultMap[result.Name] = result.Stops
+					if result.Status == models.StatusReachable {
+						resultMap[result.Name] = *result.Stops
+					}
 				}
 				for name, stops := range tt.mockStops {
 					if resultMap[name] != stops {
 						t.Errorf("for ship %s: expected %d stops, got %d", name, stops, resultMap[name])
 					}
 				}
+
+				// Verify skipped ships are reported with their reason
+				if len(response.Skipped) != len(tt.mockSkipped) {
+					t.Fatalf("expected %d skipped ships, got %d", len(tt.mockSkipped), len(response.Skipped))
+				}
+				for i, skipped := range tt.mockSkipped {
+					if got := response.Skipped[i]; got.Name != skipped.Starship.Name || got.Reason != skipped.Reason {
+						t.Errorf("expected skipped ship %s (%s), got %s (%s)", skipped.Starship.Name, skipped.Reason, got.Name, got.Reason)
+					}
+				}
+			}
+		})
+	}
+}
+
+// TestCalculateStopsBody verifies the POST variant of the stop calculation endpoint.
+// It tests various scenarios including:
+// - Valid bodies with ship filters, sorting and summary detail
+// - Unreachable and unknown ships sorted last, whatever the order
+// - The resupply dwell option, passed to the calculator and echoed back
+// - Strict body validation: content type, unknown fields, size limit, trailing data
+// - Invalid option values and distances
+func TestCalculateStopsBody(t *testing.T) {
+	mockStops := map[string]int64{
+		"X-wing":            59,
+		"Y-wing":            74,
+		"Millennium Falcon": 9,
+	}
+	mockSkipped := []service.SkippedStarship{
+		{Starship: domain.Starship{Name: "Death Star"}, Reason: service.SkipZeroSpeed},
+		{Starship: domain.Starship{Name: "Executor", UnknownMGLT: true}, Reason: service.SkipUnknownMGLT},
+	}
+
+	tests := []struct {
+		name           string   // Description of the test case
+		contentType    string   // Content-Type header of the request
+		body           string   // Request body
+		expectedStatus int      // Expected HTTP status code
+		expectedNames  []string // Expected result names, in order
+		expectSummary  bool     // Whether a summary is expected instead of results
+		expectedDwell  int64    // Expected resupply hours per stop
+	}{
+		{
+			name:           "valid body with defaults",
+			contentType:    "application/json",
+			body:           `{"distance":"1000000"}`,
+			expectedStatus: http.StatusOK,
+			expectedNames:  []string{"Millennium Falcon", "X-wing", "Y-wing", "Executor", "Death Star"},
+		},
+		{
+			name:           "descending stops keep unreachable last",
+			contentType:    "application/json",
+			body:           `{"distance":"1000000","order":"desc"}`,
+			expectedStatus: http.StatusOK,
+			expectedNames:  []string{"Y-wing", "X-wing", "Millennium Falcon", "Executor", "Death Star"},
+		},
+		{
+			name:           "ship filter sorted by name descending",
+			contentType:    "application/json; charset=utf-8",
+			body:           `{"distance":"1000000","ships":["x-WING","Millennium Falcon"],"sort":"name","order":"desc"}`,
+			expectedStatus: http.StatusOK,
+			expectedNames:  []string{"X-wing", "Millennium Falcon"},
+		},
+		{
+			name:           "dwell option",
+			contentType:    "application/json",
+			body:           `{"distance":"1000000","ships":["X-wing"],"dwell":"2 days"}`,
+			expectedStatus: http.StatusOK,
+			expectedNames:  []string{"X-wing"},
+			expectedDwell:  48,
+		},
+		{
+			name:           "invalid dwell",
+			contentType:    "application/json",
+			body:           `{"distance":"1000000","dwell":"a while"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "summary detail",
+			contentType:    "application/json",
+			body:           `{"distance":"1000000","detail":"summary"}`,
+			expectedStatus: http.StatusOK,
+			expectSummary:  true,
+		},
+		{
+			name:           "wrong content type",
+			contentType:    "text/plain",
+			body:           `{"distance":"1000000"}`,
+			expectedStatus: http.StatusUnsupportedMediaType,
+		},
+		{
+			name:           "unknown field",
+			contentType:    "application/json",
+			body:           `{"distance":"1000000","speed":"fast"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "trailing data",
+			contentType:    "application/json",
+			body:           `{"distance":"1000000"}{"distance":"5"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "body too large",
+			contentType:    "application/json",
+			body:           `{"distance":"1000000","ships":["` + strings.Repeat("x", maxRequestBodySize) + `"]}`,
+			expectedStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:           "empty body",
+			contentType:    "application/json",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid sort",
+			contentType:    "application/json",
+			body:           `{"distance":"1000000","sort":"speed"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "missing distance",
+			contentType:    "application/json",
+			body:           `{"ships":["X-wing"]}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid distance",
+			contentType:    "application/json",
+			body:           `{"distance":"-1"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calculator := &mockCalculator{stops: mockStops, skipped: mockSkipped}
+			h := &StopsHandler{calculator: calculator}
+
+			req := httptest.NewRequest(http.MethodPost, "/calculate-stops", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			rec := httptest.NewRecorder()
+
+			h.HandleCalculate(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.expectedStatus, rec.Code, rec.Body.String())
+			}
+			if rec.Code != http.StatusOK {
+				var errResp models.ErrorResponse
+				if err := json.NewDecoder(rec.Body).Decode(&errResp); err != nil || errResp.Code != tt.expectedStatus {
+					t.Errorf("expected ErrorResponse with code %d, got %+v (%v)", tt.expectedStatus, errResp, err)
+				}
+				return
+			}
+
+			var response models.StopsResponse
+			if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if response.Dwell != tt.expectedDwell || calculator.opts.DwellHours != tt.expectedDwell {
+				t.Errorf("expected dwell %d, got %d in response and %d in calculator", tt.expectedDwell, response.Dwell, calculator.opts.DwellHours)
+			}
+
+			if tt.expectSummary {
+				if response.Summary == nil || response.Results != nil {
+					t.Fatalf("expected summary without results, got %+v", response)
+				}
+				want := models.StopsSummary{Ships: 5, Unreachable: 1, Unknown: 1, MinStops: 9, MaxStops: 74}
+				if *response.Summary != want {
+					t.Errorf("expected summary %+v, got %+v", want, *response.Summary)
+				}
+				return
+			}
+
+			var names []string
+			for _, result := range response.Results {
+				names = append(names, result.Name)
+			}
+			if strings.Join(names, ",") != strings.Join(tt.expectedNames, ",") {
+				t.Errorf("expected results %v, got %v", tt.expectedNames, names)
+			}
+		})
+	}
+}
+
+// TestCalculateStops_emptyResults verifies that a full response keeps an empty results
+// list when no starship matches, while a summary response leaves the results out.
+func TestCalculateStops_emptyResults(t *testing.T) {
+	tests := []struct {
+		name        string // Test case description
+		body        string // Request body
+		wantResults bool   // Whether the results key is expected
+	}{
+		{name: "full detail", body: `{"distance":"1000000","ships":["Slave I"]}`, wantResults: true},
+		{name: "summary detail", body: `{"distance":"1000000","detail":"summary"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &StopsHandler{calculator: &mockCalculator{stops: map[string]int64{"X-wing": 59}}}
+
+			req := httptest.NewRequest(http.MethodPost, "/calculate-stops", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			h.HandleCalculate(rec, req)
+
+			if rec.Code != http.StatusOK {
+				t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+			}
+			var response map[string]json.RawMessage
+			if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			results, ok := response["results"]
+			if ok != tt.wantResults {
+				t.Fatalf("expected results key %v, got response %v", tt.wantResults, response)
+			}
+			if ok && string(results) != "[]" {
+				t.Errorf("expected empty results, got %s", results)
+			}
+		})
+	}
+}
+
+// TestCalculateStops_travelError verifies that a reachable result whose travel time can't
+// be computed says why instead of silently leaving the travel out.
+func TestCalculateStops_travelError(t *testing.T) {
+	h := &StopsHandler{calculator: &mockCalculator{
+		stops:        map[string]int64{"Slow": 384307168202282325},
+		travelErrors: map[string]error{"Slow": fmt.Errorf("travel of Slow: %w", service.ErrOverflow)},
+	}}
+
+	req := httptest.NewRequest(http.MethodGet, "/calculate-stops/9223372036854775807?dwell=1%20year", nil)
+	rec := httptest.NewRecorder()
+	h.HandleCalculate(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	var response models.StopsResponse
+	if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(response.Results) != 1 || response.Results[0].Travel != nil || !strings.Contains(response.Results[0].TravelError, service.ErrOverflow.Error()) {
+		t.Errorf("expected the travel replaced by its error, got %+v", response.Results)
+	}
+}
+
+// TestCalculateStops_model verifies that the model option selects the calculator and
+// that every response states the model that produced it.
+func TestCalculateStops_model(t *testing.T) {
+	tests := []struct {
+		name           string // Test case description
+		method         string // HTTP method
+		urlPath        string // Requested URL
+		body           string // JSON body, for POST requests
+		expectedStatus int    // Expected HTTP status code
+		expectedModel  string // Expected model in the response
+		expectedStops  int64  // Expected X-wing stops, telling which calculator answered
+	}{
+		{
+			name:           "sublight by default",
+			method:         http.MethodGet,
+			urlPath:        "/calculate-stops/1000000",
+			expectedStatus: http.StatusOK,
+			expectedModel:  models.ModelSublight,
+			expectedStops:  59,
+		},
+		{
+			name:           "hyperdrive from the query",
+			method:         http.MethodGet,
+			urlPath:        "/calculate-stops/1000000?model=hyperdrive",
+			expectedStatus: http.StatusOK,
+			expectedModel:  models.ModelHyperdrive,
+			expectedStops:  29,
+		},
+		{
+			name:           "hyperdrive from the body",
+			method:         http.MethodPost,
+			urlPath:        "/calculate-stops",
+			body:           `{"distance":"1000000","model":"hyperdrive"}`,
+			expectedStatus: http.StatusOK,
+			expectedModel:  models.ModelHyperdrive,
+			expectedStops:  29,
+		},
+		{
+			name:           "unknown model",
+			method:         http.MethodGet,
+			urlPath:        "/calculate-stops/1000000?model=warp",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &StopsHandler{
+				calculator: &mockCalculator{stops: map[string]int64{"X-wing": 59}},
+				calculators: map[string]service.CalculatorService{
+					models.ModelHyperdrive: &mockCalculator{stops: map[string]int64{"X-wing": 29}},
+				},
+			}
+
+			req := httptest.NewRequest(tt.method, tt.urlPath, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			h.HandleCalculate(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.expectedStatus, rec.Code, rec.Body.String())
+			}
+			if rec.Code != http.StatusOK {
+				return
+			}
+
+			var response models.StopsResponse
+			if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if response.Model != tt.expectedModel {
+				t.Errorf("expected model %q, got %q", tt.expectedModel, response.Model)
+			}
+			if len(response.Results) != 1 || *response.Results[0].Stops != tt.expectedStops {
+				t.Errorf("expected %d stops, got %+v", tt.expectedStops, response.Results)
+			}
+		})
+	}
+}
+
+// TestCalculateStops_strategy verifies that requests pick a registered stop strategy,
+// that the response states it, and that unknown strategies are rejected.
+func TestCalculateStops_strategy(t *testing.T) {
+	tests := []struct {
+		name             string // Test case description
+		urlPath          string // Requested URL
+		expectedStatus   int    // Expected HTTP status code
+		expectedStrategy string // Expected strategy in the response
+		expectedMargin   *int64 // Expected margin in the response
+		expectedStops    int64  // Expected stops of the strategy passed to the calculator for an X-wing covering exactly its range
+	}{
+		{name: "exact by default", urlPath: "/calculate-stops/1000000", expectedStatus: http.StatusOK, expectedStrategy: service.StrategyExact, expectedStops: 0},
+		{name: "ceiling", urlPath: "/calculate-stops/1000000?strategy=ceiling", expectedStatus: http.StatusOK, expectedStrategy: service.StrategyCeiling, expectedStops: 1},
+		{name: "margin", urlPath: "/calculate-stops/1000000?strategy=margin", expectedStatus: http.StatusOK, expectedStrategy: service.StrategyMargin, expectedMargin: int64Ptr(10), expectedStops: 1},
+		{name: "margin percentage", urlPath: "/calculate-stops/1000000?strategy=margin&margin=0", expectedStatus: http.StatusOK, expectedStrategy: service.StrategyMargin, expectedMargin: int64Ptr(0), expectedStops: 0},
+		{name: "margin percentage with sign", urlPath: "/calculate-stops/16800?strategy=margin&margin=15%25", expectedStatus: http.StatusOK, expectedStrategy: service.StrategyMargin, expectedMargin: int64Ptr(15), expectedStops: 1},
+		{name: "margin out of range", urlPath: "/calculate-stops/1000000?strategy=margin&margin=100", expectedStatus: http.StatusBadRequest},
+		{name: "invalid margin", urlPath: "/calculate-stops/1000000?strategy=margin&margin=some", expectedStatus: http.StatusBadRequest},
+		{name: "margin without the margin strategy", urlPath: "/calculate-stops/1000000?margin=15", expectedStatus: http.StatusBadRequest},
+		{name: "unknown strategy", urlPath: "/calculate-stops/1000000?strategy=guess", expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calculator := &mockCalculator{stops: map[string]int64{"X-wing": 59}}
+			h := &StopsHandler{calculator: calculator}
+
+			req := httptest.NewRequest(http.MethodGet, tt.urlPath, nil)
+			rec := httptest.NewRecorder()
+			h.HandleCalculate(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.expectedStatus, rec.Code, rec.Body.String())
+			}
+			if rec.Code != http.StatusOK {
+				return
+			}
+
+			var response models.StopsResponse
+			if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if response.Strategy != tt.expectedStrategy {
+				t.Errorf("expected strategy %q, got %q", tt.expectedStrategy, response.Strategy)
+			}
+			if (response.Margin == nil) != (tt.expectedMargin == nil) || (response.Margin != nil && *response.Margin != *tt.expectedMargin) {
+				t.Errorf("expected margin %v, got %v", tt.expectedMargin, response.Margin)
+			}
+			xwing := domain.Starship{Name: "X-wing", MGLT: 100, Consumables: "1 week"}
+			if stops, err := calculator.opts.Strategy.Stops(xwing, 16800); err != nil || stops != tt.expectedStops {
+				t.Errorf("expected the %s strategy to count %d stops, got %d (%v)", tt.expectedStrategy, tt.expectedStops, stops, err)
+			}
+		})
+	}
+}
+
+// TestCalculateStops_reserve verifies that a consumables reserve is passed to the calculator
+// and echoed in the response, and that invalid reserves are rejected.
+func TestCalculateStops_reserve(t *testing.T) {
+	tests := []struct {
+		name            string          // Test case description
+		urlPath         string          // Requested URL
+		expectedStatus  int             // Expected HTTP status code
+		expectedReserve service.Reserve // Expected reserve passed to the calculator and echoed
+	}{
+		{name: "no reserve", urlPath: "/calculate-stops/1000000", expectedStatus: http.StatusOK},
+		{name: "percentage", urlPath: "/calculate-stops/1000000?reserve=15%25", expectedStatus: http.StatusOK, expectedReserve: service.Reserve{Percent: 15}},
+		{name: "duration", urlPath: "/calculate-stops/1000000?reserve=1%20week", expectedStatus: http.StatusOK, expectedReserve: service.Reserve{Hours: 168}},
+		{name: "whole autonomy", urlPath: "/calculate-stops/1000000?reserve=100%25", expectedStatus: http.StatusBadRequest},
+		{name: "invalid duration", urlPath: "/calculate-stops/1000000?reserve=soon", expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calculator := &mockCalculator{stops: map[string]int64{"X-wing": 59}}
+			h := &StopsHandler{calculator: calculator}
+
+			req := httptest.NewRequest(http.MethodGet, tt.urlPath, nil)
+			rec := httptest.NewRecorder()
+			h.HandleCalculate(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.expectedStatus, rec.Code, rec.Body.String())
+			}
+			if rec.Code != http.StatusOK {
+				return
+			}
+
+			var response models.StopsResponse
+			if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if calculator.opts.Reserve != tt.expectedReserve {
+				t.Errorf("expected reserve %+v passed to the calculator, got %+v", tt.expectedReserve, calculator.opts.Reserve)
+			}
+			var echoed service.Reserve
+			if response.Reserve != nil {
+				echoed = service.Reserve{Percent: response.Reserve.Percent, Hours: response.Reserve.Hours}
+			}
+			if echoed != tt.expectedReserve {
+				t.Errorf("expected reserve %+v in the response, got %+v", tt.expectedReserve, response.Reserve)
+			}
+		})
+	}
+}
+
+// TestCalculateStops_reserveShips verifies that starships unable to keep the reserve are
+// reported as skipped, and only fail the request when the ships filter asks for them.
+func TestCalculateStops_reserveShips(t *testing.T) {
+	tests := []struct {
+		name           string   // Test case description
+		body           string   // Request body
+		expectedStatus int      // Expected HTTP status code
+		expectedNames  []string // Expected result names, in order
+	}{
+		{
+			name:           "whole fleet",
+			body:           `{"distance":"1000000","reserve":"1 week"}`,
+			expectedStatus: http.StatusOK,
+			expectedNames:  []string{"Star Destroyer", "X-wing"},
+		},
+		{
+			name:           "other starship requested",
+			body:           `{"distance":"1000000","reserve":"1 week","ships":["Star Destroyer"]}`,
+			expectedStatus: http.StatusOK,
+			expectedNames:  []string{"Star Destroyer"},
+		},
+		{
+			name:           "starship left without autonomy requested",
+			body:           `{"distance":"1000000","reserve":"1 week","ships":["Star Destroyer","x-wing"]}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calculator := &mockCalculator{
+				stops: map[string]int64{"Star Destroyer": 0},
+				skipped: []service.SkippedStarship{{
+					Starship: domain.Starship{Name: "X-wing", MGLT: 100, Consumables: "1 week"},
+					Reason:   service.SkipReserveTooLarge,
+					Detail:   service.ErrReserveTooLarge.Error(),
+				}},
+			}
+			h := &StopsHandler{calculator: calculator}
+
+			req := httptest.NewRequest(http.MethodPost, "/calculate-stops", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			h.HandleCalculate(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.expectedStatus, rec.Code, rec.Body.String())
+			}
+			if rec.Code != http.StatusOK {
+				if !strings.Contains(rec.Body.String(), "X-wing") {
+					t.Errorf("expected the error to name the X-wing, got %s", rec.Body.String())
+				}
+				return
+			}
+
+			var response models.StopsResponse
+			if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if len(response.Results) != len(tt.expectedNames) {
+				t.Fatalf("expected %d results, got %+v", len(tt.expectedNames), response.Results)
+			}
+			for i, result := range response.Results {
+				if result.Name != tt.expectedNames[i] {
+					t.Errorf("expected result %d to be %s, got %s", i, tt.expectedNames[i], result.Name)
+				}
+				if result.Name == "X-wing" && result.Status != models.StatusUnreachable {
+					t.Errorf("expected the X-wing to be unreachable, got %s", result.Status)
+				}
+			}
+		})
+	}
+}
+
+// TestCalculateStops_overrides verifies what-if requests overriding starship values.
+// It tests scenarios including:
+// - Overrides passed to the calculator and marked on the results
+// - Overrides without values, with invalid values or matching no starship
+func TestCalculateStops_overrides(t *testing.T) {
+	tests := []struct {
+		name           string // Test case description
+		body           string // JSON request body
+		mockError      error  // Mocked error from the calculator
+		expectedStatus int    // Expected HTTP status code
+	}{
+		{
+			name:           "overridden consumables",
+			body:           `{"distance":"1000000","overrides":{"Millennium Falcon":{"consumables":"3 months"}}}`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "override without values",
+			body:           `{"distance":"1000000","overrides":{"Millennium Falcon":{}}}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "negative MGLT",
+			body:           `{"distance":"1000000","overrides":{"Millennium Falcon":{"mglt":-5}}}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid consumables",
+			body:           `{"distance":"1000000","overrides":{"Millennium Falcon":{"consumables":"forever"}}}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "unknown starship",
+			body:           `{"distance":"1000000","overrides":{"Falcon":{"mglt":80}}}`,
+			mockError:      &service.OverrideError{Unknown: []*service.NotFoundError{{Key: "Falcon", Suggestions: []string{"Millennium Falcon"}}}},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calculator := &mockCalculator{
+				stops:      map[string]int64{"Millennium Falcon": 6, "X-wing": 59},
+				overridden: map[string][]string{"Millennium Falcon": {service.OverrideConsumables}},
+				err:        tt.mockError,
+			}
+			h := &StopsHandler{calculator: calculator}
+
+			req := httptest.NewRequest(http.MethodPost, "/calculate-stops", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			h.HandleCalculate(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.expectedStatus, rec.Code, rec.Body.String())
+			}
+			if rec.Code != http.StatusOK {
+				return
+			}
+
+			override, ok := calculator.opts.Overrides["Millennium Falcon"]
+			if !ok || override.Consumables == nil || *override.Consumables != "3 months" || override.MGLT != nil {
+				t.Errorf("expected the consumables override passed to the calculator, got %+v", calculator.opts.Overrides)
+			}
+
+			var response models.StopsResponse
+			if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			for _, result := range response.Results {
+				overridden := strings.Join(result.Overridden, ",")
+				if (result.Name == "Millennium Falcon") != (overridden == service.OverrideConsumables) {
+					t.Errorf("unexpected overridden values %q for %s", overridden, result.Name)
+				}
 			}
 		})
 	}
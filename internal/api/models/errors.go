@@ -7,9 +7,10 @@ import (
 
 // ErrorResponse represents a standard error response for the API.
 type ErrorResponse struct {
-	Error   string `json:"error"`   // HTTP status text (e.g., "Bad Request")
-	Code    int    `json:"code"`    // HTTP status code (e.g., 400)
-	Message string `json:"message"` // Detailed error message
+	Error       string   `json:"error"`                 // HTTP status text (e.g., "Bad Request")
+	Code        int      `json:"code"`                  // HTTP status code (e.g., 400)
+	Message     string   `json:"message"`               // Detailed error message
+	Suggestions []string `json:"suggestions,omitempty"` // Close matches, when a lookup found nothing
 }
 
 // WriteError sends a JSON-formatted error response to the client.
@@ -27,3 +28,19 @@ func WriteError(w http.ResponseWriter, code int, message string) {
 		Message: message,
 	})
 }
+
+// WriteNotFound sends a 404 error response, listing close matches the client may have meant.
+//
+// Example:
+//
+//	models.WriteNotFound(w, `Starship "X-wnig" not found`, []string{"X-wing"})
+func WriteNotFound(w http.ResponseWriter, message string, suggestions []string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusNotFound)
+	json.NewEncoder(w).Encode(ErrorResponse{
+		Error:       http.StatusText(http.StatusNotFound),
+		Code:        http.StatusNotFound,
+		Message:     message,
+		Suggestions: suggestions,
+	})
+}
@@ -1,6 +1,152 @@
 package models
 
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// MaxBatchDistances is the largest number of distances accepted in a single batch
+const MaxBatchDistances = 1000
+
+// MaxRecommendations is the largest number of recommendations returned at once
+const MaxRecommendations = 50
+
+// Accepted values for StopsRequest options
+const (
+	SortByStops   = "stops"   // Sort results by number of stops, then name
+	SortByName    = "name"    // Sort results alphabetically by name
+	OrderAsc      = "asc"     // Ascending order
+	OrderDesc     = "desc"    // Descending order
+	DetailFull    = "full"    // Return every starship result
+	DetailSummary = "summary" // Return only aggregated figures
+)
+
+// Travel models a calculation can use
+const (
+	ModelSublight   = "sublight"   // Sublight travel at the starship's MGLT, the default
+	ModelHyperdrive = "hyperdrive" // Hyperspace travel at the MGLT divided by the hyperdrive rating
+)
+
+// ParseModel checks a travel model, returning ModelSublight when it is omitted
+func ParseModel(model string) (string, error) {
+	switch model {
+	case "":
+		return ModelSublight, nil
+	case ModelSublight, ModelHyperdrive:
+		return model, nil
+	default:
+		return "", fmt.Errorf("model must be %q or %q, got %q", ModelSublight, ModelHyperdrive, model)
+	}
+}
+
 // StopsRequest represents the payload for a request to calculate starship stops.
 type StopsRequest struct {
-	Distance string `json:"distance"` // Distance to travel in mega lights (MGLT)
+	Distance string   `json:"distance"`           // Distance to travel in mega lights (MGLT)
+	Ships    []string `json:"ships,omitempty"`    // Only report these starships, matched case-insensitively
+	Sort     string   `json:"sort,omitempty"`     // "stops" (default) or "name"
+	Order    string   `json:"order,omitempty"`    // "asc" (default) or "desc"
+	Detail   string   `json:"detail,omitempty"`   // "full" (default) or "summary"
+	Dwell    string   `json:"dwell,omitempty"`    // Time spent resupplying at every stop, e.g. "2 days"
+	Model    string   `json:"model,omitempty"`    // "sublight" (default) or "hyperdrive"
+	Strategy string   `json:"strategy,omitempty"` // Registered stop strategy, "exact" by default
+	Margin   string   `json:"margin,omitempty"`   // Safety margin percentage of the margin strategy, e.g. "15"
+	Reserve  string   `json:"reserve,omitempty"`  // Consumables kept unused at every stop, e.g. "15%" or "1 week"
+
+	Overrides map[string]Override `json:"overrides,omitempty"` // Starship values replaced for this request, keyed by SWAPI id or name
+}
+
+// Validate checks the request options and fills in defaults for the omitted ones.
+// The distance and dwell are validated by the parser package, the strategy by its registry.
+func (r *StopsRequest) Validate() error {
+	if strings.TrimSpace(r.Distance) == "" {
+		return errors.New("distance is required")
+	}
+
+	if r.Sort == "" {
+		r.Sort = SortByStops
+	}
+	if r.Sort != SortByStops && r.Sort != SortByName {
+		return fmt.Errorf("sort must be %q or %q, got %q", SortByStops, SortByName, r.Sort)
+	}
+
+	if r.Order == "" {
+		r.Order = OrderAsc
+	}
+	if r.Order != OrderAsc && r.Order != OrderDesc {
+		return fmt.Errorf("order must be %q or %q, got %q", OrderAsc, OrderDesc, r.Order)
+	}
+
+	if r.Detail == "" {
+		r.Detail = DetailFull
+	}
+	if r.Detail != DetailFull && r.Detail != DetailSummary {
+		return fmt.Errorf("detail must be %q or %q, got %q", DetailFull, DetailSummary, r.Detail)
+	}
+
+	model, err := ParseModel(r.Model)
+	if err != nil {
+		return err
+	}
+	r.Model = model
+
+	for _, ship := range r.Ships {
+		if strings.TrimSpace(ship) == "" {
+			return errors.New("ships must not contain empty names")
+		}
+	}
+	return validateOverrides(r.Overrides)
+}
+
+// BatchRequest represents the payload for calculating stops for many distances at once.
+type BatchRequest struct {
+	Distances []string `json:"distances"`          // Distances to travel in mega lights (MGLT)
+	Dwell     string   `json:"dwell,omitempty"`    // Time spent resupplying at every stop, e.g. "2 days"
+	Model     string   `json:"model,omitempty"`    // "sublight" (default) or "hyperdrive"
+	Strategy  string   `json:"strategy,omitempty"` // Registered stop strategy, "exact" by default
+	Margin    string   `json:"margin,omitempty"`   // Safety margin percentage of the margin strategy, e.g. "15"
+	Reserve   string   `json:"reserve,omitempty"`  // Consumables kept unused at every stop, e.g. "15%" or "1 week"
+
+	Overrides map[string]Override `json:"overrides,omitempty"` // Starship values replaced for this request, keyed by SWAPI id or name
+}
+
+// Override replaces values of a starship for a single request, for what-if analysis
+type Override struct {
+	MGLT        *int    `json:"mglt,omitempty"`        // Replacement MGLT, must be positive
+	Consumables *string `json:"consumables,omitempty"` // Replacement consumables, e.g. "3 months"
+}
+
+// validateOverrides checks that every override replaces at least one value and that
+// replacement speeds are positive. Consumables are validated by the parser package.
+func validateOverrides(overrides map[string]Override) error {
+	for key, override := range overrides {
+		if strings.TrimSpace(key) == "" {
+			return errors.New("overrides must not contain empty starship keys")
+		}
+		if override.MGLT == nil && override.Consumables == nil {
+			return fmt.Errorf("overrides[%q] must set mglt or consumables", key)
+		}
+		if override.MGLT != nil && *override.MGLT <= 0 {
+			return fmt.Errorf("overrides[%q].mglt must be positive, got %d", key, *override.MGLT)
+		}
+	}
+	return nil
+}
+
+// Validate checks the size of the batch and fills in the default model. Each distance is
+// validated on its own by parser.ParseDistance, so a bad entry doesn't fail the whole batch.
+func (r *BatchRequest) Validate() error {
+	if len(r.Distances) == 0 {
+		return errors.New("distances must contain at least one distance")
+	}
+	if len(r.Distances) > MaxBatchDistances {
+		return fmt.Errorf("distances must not contain more than %d entries, got %d", MaxBatchDistances, len(r.Distances))
+	}
+
+	model, err := ParseModel(r.Model)
+	if err != nil {
+		return err
+	}
+	r.Model = model
+	return validateOverrides(r.Overrides)
 }
@@ -1,32 +1,298 @@
 package models
 
 import (
+	"encoding/json"
 	"sort"
 	"strings"
 )
 
+// Statuses of a starship's calculation result
+const (
+	StatusReachable   = "reachable"   // The starship covers the distance with Stops stops
+	StatusUnreachable = "unreachable" // The starship can't move, it never covers the distance
+	StatusUnknown     = "unknown"     // The starship's data can't tell whether it covers the distance
+)
+
 // Result represents a single starship's calculation result
 type Result struct {
-	Name  string `json:"name"`
-	Stops int    `json:"stops"`
+	Name   string  `json:"name"`
+	Status string  `json:"status"`           // One of the Status* values
+	Stops  *int64  `json:"stops,omitempty"`  // Number of stops, only when the status is reachable
+	Travel *Travel `json:"travel,omitempty"` // Voyage duration, only when the status is reachable
+
+	TravelError string   `json:"travel_error,omitempty"` // Why a reachable result has no travel, such as a dwell too long to represent
+	Overridden  []string `json:"overridden,omitempty"`   // Starship values replaced by the request overrides, "mglt" or "consumables"
+}
+
+// Travel represents how long a starship takes to cover the distance
+type Travel struct {
+	Hours      int64  `json:"travel_hours"`  // Hours spent moving, distance / MGLT rounded up
+	Legs       int64  `json:"legs"`          // Stretches travelled between stops
+	LegHours   int64  `json:"hours_per_leg"` // Hours of a full leg, the last leg may be shorter
+	DwellHours int64  `json:"dwell_hours"`   // Hours spent resupplying at the stops
+	TotalHours int64  `json:"total_hours"`   // Travel and dwell hours
+	Duration   string `json:"duration"`      // Total hours in words, e.g. "3 months 2 days"
+}
+
+// Reachable creates the result of a starship covering the distance with the given stops
+func Reachable(name string, stops int64) Result {
+	return Result{Name: name, Status: StatusReachable, Stops: &stops}
 }
 
 // StopsResponse represents the complete API response
 type StopsResponse struct {
+	Distance int64             `json:"distance"`
+	Model    string            `json:"model"`                          // Travel model that produced the results
+	Strategy string            `json:"strategy"`                       // Stop strategy that counted the stops
+	Margin   *int64            `json:"margin,omitempty"`               // Safety margin percentage, with the margin strategy
+	Reserve  *Reserve          `json:"reserve,omitempty"`              // Consumables reserve, when one was requested
+	Dwell    int64             `json:"dwell_hours_per_stop,omitempty"` // Hours spent resupplying at every stop
+	Source   string            `json:"source,omitempty"`               // Starship source that answered, when fallbacks are configured
+	Summary  *StopsSummary     `json:"summary,omitempty"`              // Aggregated figures, when requested with the summary detail
+	Results  []Result          `json:"results"`                        // Omitted with the summary detail
+	Skipped  []SkippedStarship `json:"skipped"`                        // Starships left out of the results, with the reason why
+}
+
+// MarshalJSON omits the results of a summary response, while a full response always
+// carries them, as an empty list when no starship matches
+func (r StopsResponse) MarshalJSON() ([]byte, error) {
+	type response StopsResponse // Drops the method to avoid recursing
+	if r.Summary == nil {
+		return json.Marshal(response(r))
+	}
+	return json.Marshal(struct {
+		response
+		Results []Result `json:"results,omitempty"` // Shadows the embedded results
+	}{response: response(r)})
+}
+
+// StopsSummary aggregates the results of a calculation
+type StopsSummary struct {
+	Ships       int   `json:"ships"`       // Number of starships in the results
+	Unreachable int   `json:"unreachable"` // Starships that never cover the distance
+	Unknown     int   `json:"unknown"`     // Starships whose data can't tell
+	MinStops    int64 `json:"min_stops"`   // Fewest stops needed by any reachable starship
+	MaxStops    int64 `json:"max_stops"`   // Most stops needed by any reachable starship
+}
+
+// BatchResult represents the outcome of a single distance within a batch
+type BatchResult struct {
+	Distance string            `json:"distance"`          // Distance as given in the request
+	Results  []Result          `json:"results,omitempty"` // Stops per starship, when the distance is valid
+	Skipped  []SkippedStarship `json:"skipped,omitempty"` // Starships left out at this distance only, with the reason why
+	Error    string            `json:"error,omitempty"`   // Why the distance was rejected, when it is not
+}
+
+// BatchResponse represents the API response for a batch of distances
+type BatchResponse struct {
+	Model    string            `json:"model"`                          // Travel model that produced the results
+	Strategy string            `json:"strategy"`                       // Stop strategy that counted the stops
+	Margin   *int64            `json:"margin,omitempty"`               // Safety margin percentage, with the margin strategy
+	Reserve  *Reserve          `json:"reserve,omitempty"`              // Consumables reserve, when one was requested
+	Source   string            `json:"source,omitempty"`               // Starship source that answered, when fallbacks are configured
+	Dwell    int64             `json:"dwell_hours_per_stop,omitempty"` // Hours spent resupplying at every stop
+	Results  []BatchResult     `json:"results"`                        // One entry per requested distance, in request order
+	Skipped  []SkippedStarship `json:"skipped"`                        // Starships left out of every result, with the reason why
+}
+
+// RangeResult represents how far a single starship goes with a stop budget
+type RangeResult struct {
+	Name        string `json:"name"`
+	Status      string `json:"status"`                 // One of the Status* values
+	MaxDistance *int64 `json:"max_distance,omitempty"` // Longest distance covered, when reachable and bounded
+	Unbounded   bool   `json:"unbounded,omitempty"`    // Whether the range exceeds the largest distance accepted
+}
+
+// RangeResponse represents the API response for a maximum range query
+type RangeResponse struct {
+	Stops   int64             `json:"stops"`             // Stop budget
+	Model   string            `json:"model"`             // Travel model that produced the results
+	Reserve *Reserve          `json:"reserve,omitempty"` // Consumables reserve, when one was requested
+	Source  string            `json:"source,omitempty"`  // Starship source that answered, when fallbacks are configured
+	Results []RangeResult     `json:"results"`           // Sorted by range, longest first
+	Skipped []SkippedStarship `json:"skipped"`           // Starships left out of the results, with the reason why
+}
+
+// Starship represents a single starship's data
+type Starship struct {
+	ID                   string    `json:"id"`
+	Name                 string    `json:"name"`
+	MGLT                 *int      `json:"mglt,omitempty"` // Omitted when SWAPI doesn't know it
+	Consumables          string    `json:"consumables"`
+	Model                string    `json:"model,omitempty"`
+	Manufacturers        []string  `json:"manufacturers,omitempty"`
+	Class                string    `json:"starship_class,omitempty"`
+	Cost                 *Quantity `json:"cost_in_credits,omitempty"`        // Omitted when SWAPI doesn't know it
+	Length               *float64  `json:"length,omitempty"`                 // Omitted when SWAPI doesn't know it, in meters
+	MaxAtmospheringSpeed *float64  `json:"max_atmosphering_speed,omitempty"` // Omitted when SWAPI doesn't know it
+	HyperdriveRating     *float64  `json:"hyperdrive_rating,omitempty"`      // Omitted when SWAPI doesn't know it, lower is faster
+	Crew                 *Quantity `json:"crew,omitempty"`                   // Omitted when SWAPI doesn't know it
+	Passengers           *Quantity `json:"passengers,omitempty"`             // Omitted when SWAPI doesn't know it
+	CargoCapacity        *Quantity `json:"cargo_capacity,omitempty"`         // Omitted when SWAPI doesn't know it, in metric tons
+}
+
+// Reserve represents the consumables a starship keeps unused at every stop
+type Reserve struct {
+	Percent int64 `json:"percent,omitempty"` // Share of the autonomy kept in reserve
+	Hours   int64 `json:"hours,omitempty"`   // Fixed duration kept in reserve
+}
+
+// Quantity represents a count that may be a range, such as a crew of "30-165"
+type Quantity struct {
+	Min int64 `json:"min"`
+	Max int64 `json:"max"`
+}
+
+// StarshipResponse represents the API response for a single starship
+type StarshipResponse struct {
+	Source   string   `json:"source,omitempty"` // Starship source that answered, when fallbacks are configured
+	Starship Starship `json:"starship"`
+}
+
+// StarshipStopsResponse represents the API response for a single starship's stops
+type StarshipStopsResponse struct {
 	Distance int64    `json:"distance"`
-	Results  []Result `json:"results"`
+	Model    string   `json:"model"`             // Travel model that produced the stops
+	Strategy string   `json:"strategy"`          // Stop strategy that counted the stops
+	Margin   *int64   `json:"margin,omitempty"`  // Safety margin percentage, with the margin strategy
+	Reserve  *Reserve `json:"reserve,omitempty"` // Consumables reserve, when one was requested
+	Source   string   `json:"source,omitempty"`  // Starship source that answered, when fallbacks are configured
+	Starship Starship `json:"starship"`
+	Dwell    int64    `json:"dwell_hours_per_stop,omitempty"` // Hours spent resupplying at every stop
+	Status   string   `json:"status"`                         // One of the Status* values
+	Stops    *int64   `json:"stops,omitempty"`                // Number of stops, only when the status is reachable
+	Reason   string   `json:"reason,omitempty"`               // Why the stops are unknown or unreachable, as in SkippedStarship
+	Travel   *Travel  `json:"travel,omitempty"`               // Voyage duration, only when the status is reachable
+
+	TravelError string `json:"travel_error,omitempty"` // Why a reachable starship has no travel, such as a dwell too long to represent
 }
 
-// SortResults sorts a slice of Results by stops (ascending), then alphabetically by name.
-func SortResults(results []Result) {
+// StarshipCapability represents a starship with its parsed autonomy
+type StarshipCapability struct {
+	Starship
+	AutonomyHours int64  `json:"autonomy_hours"` // Hours of travel the consumables last
+	MaxRange      *int64 `json:"max_range"`      // MGLT covered between two stops, null when it overflows an int64
+}
+
+// SkippedStarship represents a starship left out of stop calculations
+type SkippedStarship struct {
+	Starship
+	Reason string `json:"reason"`           // Machine readable reason, such as "unknown_mglt", "invalid_consumables" or "zero_speed"
+	Detail string `json:"detail,omitempty"` // Human readable explanation
+}
+
+// StarshipsResponse represents the API response listing every starship
+type StarshipsResponse struct {
+	Source    string               `json:"source,omitempty"` // Starship source that answered, when fallbacks are configured
+	Starships []StarshipCapability `json:"starships"`
+	Skipped   []SkippedStarship    `json:"skipped"`
+}
+
+// Recommendation represents a starship recommended for a distance
+type Recommendation struct {
+	Rank        int      `json:"rank"` // Position in the ranking, starting at 1
+	Starship    Starship `json:"starship"`
+	Stops       int64    `json:"stops"`
+	Travel      *Travel  `json:"travel,omitempty"`       // Voyage duration, omitted when it can't be computed
+	TravelError string   `json:"travel_error,omitempty"` // Why the travel is omitted, such as a dwell too long to represent
+}
+
+// RecommendResponse represents the API response for a fleet recommendation
+type RecommendResponse struct {
+	Distance        int64            `json:"distance"`
+	Model           string           `json:"model"`                          // Travel model that produced the ranking
+	Strategy        string           `json:"strategy"`                       // Stop strategy that counted the stops
+	Margin          *int64           `json:"margin,omitempty"`               // Safety margin percentage, with the margin strategy
+	Reserve         *Reserve         `json:"reserve,omitempty"`              // Consumables reserve, when one was requested
+	Dwell           int64            `json:"dwell_hours_per_stop,omitempty"` // Hours spent resupplying at every stop
+	Source          string           `json:"source,omitempty"`               // Starship source that answered, when fallbacks are configured
+	Recommendations []Recommendation `json:"recommendations"`                // Ranked by stops, then travel time
+}
+
+// SortResults sorts a slice of Results by stops, then alphabetically by name.
+// Starships with unknown stops come after the reachable ones, and unreachable starships come last,
+// whatever the order ("asc" or "desc") of the stops.
+func SortResults(results []Result, order string) {
 	sort.Slice(results, func(i, j int) bool {
-		if results[i].Stops != results[j].Stops {
-			return results[i].Stops < results[j].Stops
+		a, b := results[i], results[j]
+		if statusRank(a.Status) != statusRank(b.Status) {
+			return statusRank(a.Status) < statusRank(b.Status)
+		}
+		if a.Stops != nil && b.Stops != nil && *a.Stops != *b.Stops {
+			if order == OrderDesc {
+				return *a.Stops > *b.Stops
+			}
+			return *a.Stops < *b.Stops
+		}
+		return strings.ToLower(a.Name) < strings.ToLower(b.Name)
+	})
+}
+
+// SortResultsByName sorts a slice of Results alphabetically by name, in the given order ("asc" or "desc").
+func SortResultsByName(results []Result, order string) {
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := strings.ToLower(results[i].Name), strings.ToLower(results[j].Name)
+		if order == OrderDesc {
+			return a > b
 		}
-		return strings.ToLower(results[i].Name) < strings.ToLower(results[j].Name)
+		return a < b
 	})
 }
 
+// SortRanges sorts a slice of RangeResults by range, longest first, then alphabetically by name.
+// Unbounded starships come first, then the bounded ones, unknown and finally unreachable starships.
+func SortRanges(results []RangeResult) {
+	sort.Slice(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if a.Unbounded != b.Unbounded {
+			return a.Unbounded
+		}
+		if statusRank(a.Status) != statusRank(b.Status) {
+			return statusRank(a.Status) < statusRank(b.Status)
+		}
+		if a.MaxDistance != nil && b.MaxDistance != nil && *a.MaxDistance != *b.MaxDistance {
+			return *a.MaxDistance > *b.MaxDistance
+		}
+		return strings.ToLower(a.Name) < strings.ToLower(b.Name)
+	})
+}
+
+// statusRank orders statuses: reachable first, unreachable last
+func statusRank(status string) int {
+	switch status {
+	case StatusReachable:
+		return 0
+	case StatusUnknown:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// Summarize computes the aggregated figures of a slice of Results.
+func Summarize(results []Result) *StopsSummary {
+	summary := &StopsSummary{Ships: len(results)}
+	reachable := 0
+	for _, result := range results {
+		switch result.Status {
+		case StatusUnreachable:
+			summary.Unreachable++
+			continue
+		case StatusUnknown:
+			summary.Unknown++
+			continue
+		}
+		if reachable == 0 || *result.Stops < summary.MinStops {
+			summary.MinStops = *result.Stops
+		}
+		if reachable == 0 || *result.Stops > summary.MaxStops {
+			summary.MaxStops = *result.Stops
+		}
+		reachable++
+	}
+	return summary
+}
+
 // HelpResponse represents the help response when no distance is provided
 type HelpResponse struct {
 	Message string `json:"message"`
@@ -1,6 +1,7 @@
 package server
 
 import (
+	"expvar"
 	"net/http"
 
 	"github.com/pvdevs/get-starships-stops/internal/api/handlers"
@@ -9,16 +10,40 @@ import (
 )
 
 // NewServer creates and configures an HTTP server with routes and middleware.
-func NewServer(cfg *config.Config) *http.Server {
+func NewServer(cfg *config.Config) (*http.Server, error) {
 	mux := http.NewServeMux()
 
-	handler := handlers.NewStopsHandler(cfg)
+	client, err := handlers.NewStarshipClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	handler, err := handlers.NewStopsHandler(client, cfg)
+	if err != nil {
+		return nil, err
+	}
+	starships, err := handlers.NewStarshipsHandler(client, cfg)
+	if err != nil {
+		return nil, err
+	}
+	recommend, err := handlers.NewRecommendHandler(client, cfg)
+	if err != nil {
+		return nil, err
+	}
 
 	// Register routes with middleware
+	mux.HandleFunc("/calculate-stops", middleware.Common(handler.HandleCalculate))
 	mux.HandleFunc("/calculate-stops/", middleware.Common(handler.HandleCalculate))
+	mux.HandleFunc("/calculate-stops/batch", middleware.Common(handler.HandleBatch))
+	mux.HandleFunc("/max-range", middleware.Common(handler.HandleMaxRange))
+	mux.HandleFunc("/max-range/", middleware.Common(handler.HandleMaxRange))
+	mux.HandleFunc("/recommend", middleware.Common(recommend.HandleRecommend))
+	mux.HandleFunc("/recommend/", middleware.Common(recommend.HandleRecommend))
+	mux.HandleFunc("/starships", middleware.Common(starships.HandleStarship))
+	mux.HandleFunc("/starships/", middleware.Common(starships.HandleStarship))
+	mux.Handle("/debug/vars", expvar.Handler())
 
 	return &http.Server{
 		Addr:    cfg.Port,
 		Handler: mux,
-	}
+	}, nil
 }
@@ -1,11 +1,33 @@
 package config
 
-import "github.com/kelseyhightower/envconfig"
+import (
+	"time"
+
+	"github.com/kelseyhightower/envconfig"
+)
 
 // Config holds application configuration values.
 type Config struct {
-	Port     string `envconfig:"PORT" default:":8080"`                  // Server port
-	SWAPIURL string `envconfig:"SWAPI_URL" default:"https://swapi.dev"` // SWAPI base URL
+	Port                   string        `envconfig:"PORT" default:":8080"`                            // Server port
+	StarshipSource         string        `envconfig:"STARSHIP_SOURCE" default:"swapi.dev"`             // Source name or URL, e.g. "swapi.tech" or "file:///path/to/snapshot.json"
+	StarshipFallbacks      []string      `envconfig:"STARSHIP_FALLBACKS"`                              // Sources tried in order when the main one fails
+	SourceTimeout          time.Duration `envconfig:"STARSHIP_SOURCE_TIMEOUT" default:"15s"`           // Maximum time a single source may take when fallbacks are configured
+	SourceFailureThreshold int           `envconfig:"STARSHIP_SOURCE_FAILURE_THRESHOLD" default:"3"`   // Consecutive failures after which a source is skipped
+	SourceCooldown         time.Duration `envconfig:"STARSHIP_SOURCE_COOLDOWN" default:"30s"`          // How long a failing source is skipped
+	SWAPIURL               string        `envconfig:"SWAPI_URL" default:"https://swapi.dev"`           // SWAPI base URL
+	SWAPITechURL           string        `envconfig:"SWAPI_TECH_URL" default:"https://www.swapi.tech"` // swapi.tech base URL
+	SWAPIMaxRetries        int           `envconfig:"SWAPI_MAX_RETRIES" default:"3"`                   // Retries for a failed SWAPI page fetch
+	SWAPIRetryBaseDelay    time.Duration `envconfig:"SWAPI_RETRY_BASE_DELAY" default:"200ms"`          // Backoff before the first SWAPI retry
+	SWAPIRetryMaxDelay     time.Duration `envconfig:"SWAPI_RETRY_MAX_DELAY" default:"5s"`              // Upper bound for a single SWAPI backoff, Retry-After included
+	SWAPIConcurrency       int           `envconfig:"SWAPI_PAGE_CONCURRENCY" default:"4"`              // SWAPI pages fetched at the same time
+	BreakerFailureRate     float64       `envconfig:"SWAPI_BREAKER_FAILURE_RATE" default:"0.5"`        // Failure rate that opens the SWAPI circuit breaker, 0 disables it
+	BreakerWindow          int           `envconfig:"SWAPI_BREAKER_WINDOW" default:"20"`               // Recent SWAPI requests the failure rate is computed over
+	BreakerMinRequests     int           `envconfig:"SWAPI_BREAKER_MIN_REQUESTS" default:"5"`          // Requests needed before the breaker may open
+	BreakerOpenTimeout     time.Duration `envconfig:"SWAPI_BREAKER_OPEN_TIMEOUT" default:"30s"`        // How long the breaker stays open before probing
+	CacheTTL               time.Duration `envconfig:"CACHE_TTL" default:"5m"`                          // How long fetched starships are reused
+	CacheRefreshAhead      time.Duration `envconfig:"CACHE_REFRESH_AHEAD" default:"30s"`               // How long before expiry the cache refreshes in the background
+	CacheDir               string        `envconfig:"CACHE_DIR"`                                       // Directory where the fetched fleet is persisted across restarts, empty disables it
+	ResupplyDwell          string        `envconfig:"RESUPPLY_DWELL"`                                  // Default time spent resupplying at every stop, e.g. "1 day", empty for none
 }
 
 // Load reads environment variables and returns a Config instance.
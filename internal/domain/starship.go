@@ -2,7 +2,34 @@ package domain
 
 // Starship represents the simplified internal structure used for business logic.
 type Starship struct {
-	Name        string // Name of the starship
-	MGLT        int    // Distance the starship can travel in mega lights per hour
-	Consumables string // Time the starship can travel without resupplying (e.g., "2 months")
+	ID           string // SWAPI id, taken from the starship URL
+	Name         string // Name of the starship
+	MGLT         int    // Distance the starship can travel in mega lights per hour
+	UnknownMGLT  bool   // Whether SWAPI has no usable MGLT for the starship, MGLT is 0 then
+	Consumables  string // Time the starship can travel without resupplying (e.g., "2 months")
+	ReserveHours int64  // Hours of consumables kept unused at every stop, set by the calculators for a reserve
+
+	Model                string   // Model of the starship (e.g., "YT-1300 light freighter")
+	Manufacturers        []string // Companies that built the starship
+	Class                string   // Starship class (e.g., "Starfighter")
+	Cost                 Quantity // Cost of the starship, in galactic credits
+	Length               Measure  // Length of the starship, in meters
+	MaxAtmospheringSpeed Measure  // Top speed in atmosphere
+	HyperdriveRating     Measure  // Hyperdrive class, lower is faster
+	Crew                 Quantity // Crew needed to operate the starship
+	Passengers           Quantity // Passengers the starship can carry
+	CargoCapacity        Quantity // Cargo the starship can carry, in metric tons
+}
+
+// Quantity is a count reported by SWAPI, possibly as a range (e.g., "30-165")
+type Quantity struct {
+	Min   int64 // Lower bound of the count
+	Max   int64 // Upper bound of the count, equal to Min for a single value
+	Known bool  // Whether SWAPI reported a usable count
+}
+
+// Measure is a decimal value reported by SWAPI, such as a length or a hyperdrive rating
+type Measure struct {
+	Value float64 // Reported value
+	Known bool    // Whether SWAPI reported a usable value
 }
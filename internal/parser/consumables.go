@@ -3,6 +3,7 @@ package parser
 import (
 	"errors"
 	"fmt"
+	"math"
 	"strconv"
 	"strings"
 )
@@ -12,9 +13,18 @@ var (
 	ErrEmptyConsumables   = errors.New("empty consumables input")
 )
 
+// Hours in each consumables unit
+const (
+	hoursPerDay   = 24
+	hoursPerWeek  = 7 * hoursPerDay
+	hoursPerMonth = 30 * hoursPerDay // simplified to 30 days per month
+	hoursPerYear  = 365 * hoursPerDay
+)
+
 // ParseConsumables converts a consumables string (e.g. "2 years", "6 months")
-// into total hours of operation. Returns an error if the format is invalid.
-func ParseConsumables(input string) (int, error) {
+// into total hours of operation. Returns an error if the format is invalid,
+// the quantity is not positive or the hours don't fit in an int64.
+func ParseConsumables(input string) (int64, error) {
 	if input == "" {
 		return 0, ErrEmptyConsumables
 	}
@@ -24,28 +34,37 @@ func ParseConsumables(input string) (int, error) {
 		return 0, ErrInvalidConsumables
 	}
 
-	quantity, err := strconv.Atoi(parts[0])
+	quantity, err := strconv.ParseInt(parts[0], 10, 64)
 	if err != nil {
+		if errors.Is(err, strconv.ErrRange) {
+			return 0, ErrInputTooLarge
+		}
 		return 0, ErrInvalidConsumables
 	}
+	if quantity <= 0 {
+		return 0, fmt.Errorf("%w: quantity must be positive", ErrInvalidConsumables)
+	}
 
 	unit := strings.ToLower(parts[1])
 	// Remove 's' from plural if present
 	unit = strings.TrimSuffix(unit, "s")
 
-	var hours int
+	var unitHours int64
 	switch unit {
 	case "year":
-		hours = quantity * 365 * 24
+		unitHours = hoursPerYear
 	case "month":
-		hours = quantity * 30 * 24 // simplified to 30 days per month
+		unitHours = hoursPerMonth
 	case "week":
-		hours = quantity * 7 * 24
+		unitHours = hoursPerWeek
 	case "day":
-		hours = quantity * 24
+		unitHours = hoursPerDay
 	default:
 		return 0, fmt.Errorf("%w: unknown unit %s", ErrInvalidConsumables, unit)
 	}
 
-	return hours, nil
+	if quantity > math.MaxInt64/unitHours {
+		return 0, ErrInputTooLarge
+	}
+	return quantity * unitHours, nil
 }
@@ -3,6 +3,8 @@ package parser
 import (
 	"errors"
 	"fmt"
+	"math/big"
+	"strings"
 	"testing"
 )
 
@@ -10,12 +12,13 @@ import (
 // It verifies the conversion of various consumables strings to hours, including:
 // - Valid input strings with units like "years", "months", "weeks", "days"
 // - Invalid input strings, such as empty strings, wrong formats, or unsupported formats
+// - Quantities that are not positive or whose hours overflow an int64
 func TestParseConsumables(t *testing.T) {
 	// Define test cases using table-driven test pattern
 	tests := []struct {
 		name        string // Description of the test case
 		input       string // Consumables string to parse
-		expected    int    // Expected result in hours
+		expected    int64  // Expected result in hours
 		expectedErr error  // Expected error
 	}{
 		{
@@ -66,6 +69,36 @@ func TestParseConsumables(t *testing.T) {
 			expected:    0,
 			expectedErr: ErrInvalidConsumables,
 		},
+		{
+			name:        "Invalid input: zero quantity",
+			input:       "0 days",
+			expected:    0,
+			expectedErr: ErrInvalidConsumables,
+		},
+		{
+			name:        "Invalid input: negative quantity",
+			input:       "-1 weeks",
+			expected:    0,
+			expectedErr: ErrInvalidConsumables,
+		},
+		{
+			name:        "Valid input: 1000000 years",
+			input:       "1000000 years",
+			expected:    8760000000, // 1000000 * 365 * 24 hours
+			expectedErr: nil,
+		},
+		{
+			name:        "Invalid input: hours overflow",
+			input:       "2000000000000000 years",
+			expected:    0,
+			expectedErr: ErrInputTooLarge,
+		},
+		{
+			name:        "Invalid input: quantity overflow",
+			input:       "99999999999999999999 days",
+			expected:    0,
+			expectedErr: ErrInputTooLarge,
+		},
 	}
 
 	// Run each test case
@@ -105,11 +138,11 @@ func TestBasicCalculation(t *testing.T) {
 	// X-wing travels 100 MGLT per hour
 	// So in 168 hours it can travel: 100 * 168 = 16800 MGLT
 
-	mglt := 100
+	var mglt int64 = 100
 	maxDistance := mglt * hours // should be 16800
 
 	// For total distance 1000000:
-	distance := 1000000
+	var distance int64 = 1000000
 	stops := distance / maxDistance // should be ~59.52, rounded to 59
 
 	fmt.Printf("Test calculation:\n")
@@ -117,3 +150,32 @@ func TestBasicCalculation(t *testing.T) {
 	fmt.Printf("Max distance: %d\n", maxDistance)
 	fmt.Printf("Stops needed: %d\n", stops)
 }
+
+// FuzzParseConsumables checks that any input either fails or yields a positive number of
+// hours equal to the quantity times the unit hours, computed without overflow.
+func FuzzParseConsumables(f *testing.F) {
+	for _, seed := range []string{"3 years", "2 months", "1 week", "6 days", "0 days", "-1 weeks", "1000000 years", "9223372036854775807 days", "unknown"} {
+		f.Add(seed)
+	}
+
+	unitHours := map[string]int64{"year": hoursPerYear, "month": hoursPerMonth, "week": hoursPerWeek, "day": hoursPerDay}
+	f.Fuzz(func(t *testing.T, input string) {
+		hours, err := ParseConsumables(input)
+		if err != nil {
+			return
+		}
+		if hours <= 0 {
+			t.Fatalf("ParseConsumables(%q) = %d, want positive hours", input, hours)
+		}
+
+		parts := strings.Split(strings.TrimSpace(input), " ")
+		quantity, ok := new(big.Int).SetString(parts[0], 10)
+		if !ok {
+			t.Fatalf("ParseConsumables(%q) accepted a non-integer quantity", input)
+		}
+		want := quantity.Mul(quantity, big.NewInt(unitHours[strings.TrimSuffix(strings.ToLower(parts[1]), "s")]))
+		if want.Cmp(big.NewInt(hours)) != 0 {
+			t.Fatalf("ParseConsumables(%q) = %d, want %s", input, hours, want)
+		}
+	})
+}
@@ -0,0 +1,43 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+)
+
+// durationUnits lists the units used by FormatHours, largest first,
+// with the same lengths ParseConsumables uses
+var durationUnits = []struct {
+	name  string
+	hours int64
+}{
+	{"year", hoursPerYear},
+	{"month", hoursPerMonth},
+	{"week", hoursPerWeek},
+	{"day", hoursPerDay},
+	{"hour", 1},
+}
+
+// FormatHours converts a number of hours into a human-readable duration
+// (e.g. "3 months 2 days"), using the consumables units. Zero hours is "0 hours".
+func FormatHours(hours int64) string {
+	if hours <= 0 {
+		return "0 hours"
+	}
+
+	var parts []string
+	for _, unit := range durationUnits {
+		quantity := hours / unit.hours
+		if quantity == 0 {
+			continue
+		}
+		hours -= quantity * unit.hours
+
+		name := unit.name
+		if quantity > 1 {
+			name += "s"
+		}
+		parts = append(parts, fmt.Sprintf("%d %s", quantity, name))
+	}
+	return strings.Join(parts, " ")
+}
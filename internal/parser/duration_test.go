@@ -0,0 +1,31 @@
+package parser
+
+import "testing"
+
+// TestFormatHours tests the FormatHours function.
+// It verifies the conversion of hours to human-readable durations, including:
+// - Single units, singular and plural
+// - Mixed units, with the units ParseConsumables accepts
+// - Zero hours
+func TestFormatHours(t *testing.T) {
+	tests := []struct {
+		name     string // Description of the test case
+		hours    int64  // Hours to format
+		expected string // Expected duration
+	}{
+		{name: "zero", hours: 0, expected: "0 hours"},
+		{name: "single hour", hours: 1, expected: "1 hour"},
+		{name: "one week", hours: 168, expected: "1 week"},
+		{name: "months and days", hours: (3*30 + 2) * 24, expected: "3 months 2 days"},
+		{name: "every unit", hours: 365*24 + 30*24 + 2*168 + 24 + 5, expected: "1 year 1 month 2 weeks 1 day 5 hours"},
+		{name: "round trip of consumables", hours: 26280, expected: "3 years"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatHours(tt.hours); got != tt.expected {
+				t.Errorf("FormatHours(%d) = %q, want %q", tt.hours, got, tt.expected)
+			}
+		})
+	}
+}
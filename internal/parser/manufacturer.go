@@ -0,0 +1,38 @@
+package parser
+
+import (
+	"strings"
+)
+
+// companySuffixes are legal forms SWAPI separates from the company name with a comma,
+// as in "Gallofree Yards, Inc."
+var companySuffixes = map[string]bool{
+	"inc":  true,
+	"inc.": true,
+	"ltd":  true,
+	"ltd.": true,
+	"llc":  true,
+}
+
+// ParseManufacturers splits a SWAPI manufacturer list (e.g. "Kuat Drive Yards, Fondor Shipyards")
+// into the individual companies, keeping legal forms such as "Inc." attached to their company.
+// "unknown" and "n/a" return no manufacturers.
+func ParseManufacturers(input string) []string {
+	switch strings.ToLower(strings.TrimSpace(input)) {
+	case "", "unknown", "n/a", "none":
+		return nil
+	}
+
+	var manufacturers []string
+	for _, part := range strings.FieldsFunc(input, func(r rune) bool { return r == ',' || r == '/' }) {
+		part = strings.TrimSpace(part)
+		switch {
+		case part == "":
+		case companySuffixes[strings.ToLower(part)] && len(manufacturers) > 0:
+			manufacturers[len(manufacturers)-1] += ", " + part
+		default:
+			manufacturers = append(manufacturers, part)
+		}
+	}
+	return manufacturers
+}
@@ -0,0 +1,37 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestParseManufacturers tests the ParseManufacturers function.
+// It verifies splitting SWAPI manufacturer lists, including:
+// - A single company and companies separated by commas or slashes
+// - Legal forms such as "Inc." kept with their company
+// - "unknown" as no manufacturers
+func TestParseManufacturers(t *testing.T) {
+	tests := []struct {
+		name     string   // Description of the test case
+		input    string   // SWAPI manufacturer list
+		expected []string // Expected manufacturers
+	}{
+		{name: "single", input: "Corellian Engineering Corporation", expected: []string{"Corellian Engineering Corporation"}},
+		{name: "comma separated", input: "Kuat Drive Yards, Fondor Shipyards", expected: []string{"Kuat Drive Yards", "Fondor Shipyards"}},
+		{name: "legal form", input: "Gallofree Yards, Inc.", expected: []string{"Gallofree Yards, Inc."}},
+		{
+			name:     "slash and legal form",
+			input:    "Theed Palace Space Vessel Engineering Corps/Nubia Star Drives, Inc.",
+			expected: []string{"Theed Palace Space Vessel Engineering Corps", "Nubia Star Drives, Inc."},
+		},
+		{name: "unknown", input: "unknown", expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseManufacturers(tt.input); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("expected %q, got %q for input: %s", tt.expected, got, tt.input)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/pvdevs/get-starships-stops/internal/domain"
+)
+
+var (
+	ErrUnknownMeasure = errors.New("unknown measure")
+	ErrInvalidMeasure = errors.New("invalid measure format")
+)
+
+// ParseMeasure converts a SWAPI decimal value such as a length, an atmospheric speed or
+// a hyperdrive rating (e.g. "34.37", "1,600", "1000km") into a domain.Measure.
+// "unknown" and "n/a" return ErrUnknownMeasure.
+func ParseMeasure(input string) (domain.Measure, error) {
+	input = strings.ToLower(strings.TrimSpace(input))
+	switch input {
+	case "", "unknown", "n/a", "none":
+		return domain.Measure{}, ErrUnknownMeasure
+	}
+
+	number := strings.TrimSpace(strings.TrimSuffix(input, "km"))
+	value, err := strconv.ParseFloat(strings.ReplaceAll(number, ",", ""), 64)
+	if err != nil || value < 0 || math.IsInf(value, 0) || math.IsNaN(value) {
+		return domain.Measure{}, fmt.Errorf("%w: %s", ErrInvalidMeasure, input)
+	}
+
+	return domain.Measure{Value: value, Known: true}, nil
+}
+
+// MeasureOrUnknown parses a SWAPI decimal value like ParseMeasure, leaving it unknown when
+// it can't be parsed. Starship sources use it so that one odd attribute never drops a starship.
+func MeasureOrUnknown(input string) domain.Measure {
+	m, _ := ParseMeasure(input)
+	return m
+}
@@ -0,0 +1,43 @@
+package parser
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/pvdevs/get-starships-stops/internal/domain"
+)
+
+// TestParseMeasure tests the ParseMeasure function.
+// It verifies the conversion of SWAPI decimal values to measures, including:
+// - Decimals, numbers with thousands separators and a "km" unit
+// - "unknown" and "n/a" as unknown
+// - Invalid formats, negative and non-finite values
+func TestParseMeasure(t *testing.T) {
+	tests := []struct {
+		name        string         // Description of the test case
+		input       string         // SWAPI value to parse
+		expected    domain.Measure // Expected measure
+		expectedErr error          // Expected error
+	}{
+		{name: "decimal", input: "34.37", expected: domain.Measure{Value: 34.37, Known: true}},
+		{name: "thousands separators", input: "1,600", expected: domain.Measure{Value: 1600, Known: true}},
+		{name: "unit", input: "1000km", expected: domain.Measure{Value: 1000, Known: true}},
+		{name: "unknown", input: "unknown", expectedErr: ErrUnknownMeasure},
+		{name: "not applicable", input: "n/a", expectedErr: ErrUnknownMeasure},
+		{name: "range", input: "30-165", expectedErr: ErrInvalidMeasure},
+		{name: "negative", input: "-1", expectedErr: ErrInvalidMeasure},
+		{name: "infinite", input: "inf", expectedErr: ErrInvalidMeasure},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseMeasure(tt.input)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Errorf("expected error %v, got %v for input: %s", tt.expectedErr, err, tt.input)
+			}
+			if got != tt.expected {
+				t.Errorf("expected %+v, got %+v for input: %s", tt.expected, got, tt.input)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pvdevs/get-starships-stops/internal/domain"
+)
+
+var (
+	ErrUnknownQuantity = errors.New("unknown quantity")
+	ErrInvalidQuantity = errors.New("invalid quantity format")
+)
+
+// ParseQuantity converts a SWAPI count such as crew, passengers or cargo capacity
+// (e.g. "843,342", "30-165", "none") into a domain.Quantity.
+// "unknown" and "n/a" return ErrUnknownQuantity, "none" is zero.
+func ParseQuantity(input string) (domain.Quantity, error) {
+	input = strings.ToLower(strings.TrimSpace(input))
+	switch input {
+	case "", "unknown", "n/a":
+		return domain.Quantity{}, ErrUnknownQuantity
+	case "none":
+		return domain.Quantity{Known: true}, nil
+	}
+
+	low, high, isRange := strings.Cut(input, "-")
+	minimum, err := parseCount(low)
+	if err != nil {
+		return domain.Quantity{}, err
+	}
+	maximum := minimum
+	if isRange {
+		if maximum, err = parseCount(high); err != nil {
+			return domain.Quantity{}, err
+		}
+		if maximum < minimum {
+			return domain.Quantity{}, fmt.Errorf("%w: range %s is reversed", ErrInvalidQuantity, input)
+		}
+	}
+
+	return domain.Quantity{Min: minimum, Max: maximum, Known: true}, nil
+}
+
+// QuantityOrUnknown parses a SWAPI count like ParseQuantity, leaving it unknown when it
+// can't be parsed. Starship sources use it so that one odd attribute never drops a starship.
+func QuantityOrUnknown(input string) domain.Quantity {
+	q, _ := ParseQuantity(input)
+	return q
+}
+
+// parseCount converts a non-negative integer with optional thousands separators
+func parseCount(input string) (int64, error) {
+	count, err := strconv.ParseInt(strings.ReplaceAll(strings.TrimSpace(input), ",", ""), 10, 64)
+	if err != nil {
+		if errors.Is(err, strconv.ErrRange) {
+			return 0, ErrInputTooLarge
+		}
+		return 0, fmt.Errorf("%w: %s", ErrInvalidQuantity, input)
+	}
+	if count < 0 {
+		return 0, fmt.Errorf("%w: %s", ErrInvalidQuantity, input)
+	}
+	return count, nil
+}
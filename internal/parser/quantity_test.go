@@ -0,0 +1,44 @@
+package parser
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/pvdevs/get-starships-stops/internal/domain"
+)
+
+// TestParseQuantity tests the ParseQuantity function.
+// It verifies the conversion of SWAPI counts to quantities, including:
+// - Plain numbers, numbers with thousands separators and ranges
+// - "none" as zero and "unknown" or "n/a" as unknown
+// - Invalid formats and values too large for an int64
+func TestParseQuantity(t *testing.T) {
+	tests := []struct {
+		name        string          // Description of the test case
+		input       string          // SWAPI count to parse
+		expected    domain.Quantity // Expected quantity
+		expectedErr error           // Expected error
+	}{
+		{name: "plain number", input: "165", expected: domain.Quantity{Min: 165, Max: 165, Known: true}},
+		{name: "thousands separators", input: "342,953", expected: domain.Quantity{Min: 342953, Max: 342953, Known: true}},
+		{name: "range", input: "30-165", expected: domain.Quantity{Min: 30, Max: 165, Known: true}},
+		{name: "none", input: "none", expected: domain.Quantity{Known: true}},
+		{name: "unknown", input: "unknown", expectedErr: ErrUnknownQuantity},
+		{name: "not applicable", input: "n/a", expectedErr: ErrUnknownQuantity},
+		{name: "decimal", input: "1.5", expectedErr: ErrInvalidQuantity},
+		{name: "reversed range", input: "165-30", expectedErr: ErrInvalidQuantity},
+		{name: "too large", input: "99999999999999999999", expectedErr: ErrInputTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseQuantity(tt.input)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Errorf("expected error %v, got %v for input: %s", tt.expectedErr, err, tt.input)
+			}
+			if got != tt.expected {
+				t.Errorf("expected %+v, got %+v for input: %s", tt.expected, got, tt.input)
+			}
+		})
+	}
+}
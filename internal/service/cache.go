@@ -0,0 +1,202 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pvdevs/get-starships-stops/internal/domain"
+)
+
+const (
+	defaultCacheTTL     = 5 * time.Minute
+	defaultRefreshAhead = 30 * time.Second
+)
+
+// CacheConfig controls how long a fetched fleet is reused before hitting the upstream again
+type CacheConfig struct {
+	TTL          time.Duration // How long a fetched fleet is considered fresh
+	RefreshAhead time.Duration // How long before expiry a background refresh is started
+	Store        FleetStore    // Optional persistence, used to warm the cache at startup
+}
+
+// CacheStats holds counters describing how the cache has been used
+type CacheStats struct {
+	Hits          int64 `json:"hits"`           // Requests served from a fresh fleet
+	Misses        int64 `json:"misses"`         // Requests that had to fetch from the upstream
+	StaleServed   int64 `json:"stale_served"`   // Requests served from an expired fleet after a failed fetch
+	RefreshErrors int64 `json:"refresh_errors"` // Failed fetches, both synchronous and in the background
+}
+
+// CachedClient wraps a StarshipClient and keeps the last fetched fleet in memory.
+// Fresh fleets are served directly, fleets close to expiry are refreshed in the
+// background, and expired fleets are served stale when a refresh fails.
+type CachedClient struct {
+	client       StarshipClient
+	ttl          time.Duration
+	refreshAhead time.Duration
+	fleetStore   FleetStore
+	now          func() time.Time
+
+	mu         sync.Mutex
+	starships  []domain.Starship
+	source     string
+	fetchedAt  time.Time
+	cached     bool
+	refreshing bool
+
+	saveMu  sync.Mutex // Serializes saves to the fleet store
+	savedAt time.Time  // Fetch time of the newest fleet handed to the fleet store
+
+	hits          atomic.Int64
+	misses        atomic.Int64
+	staleServed   atomic.Int64
+	refreshErrors atomic.Int64
+}
+
+// NewCachedClient creates a new caching wrapper around the provided client.
+// When a store is configured, the cache starts from the stored fleet and refreshes
+// it in the background if it is already due.
+func NewCachedClient(client StarshipClient, config CacheConfig) *CachedClient {
+	if config.TTL <= 0 {
+		config.TTL = defaultCacheTTL
+	}
+	if config.RefreshAhead <= 0 || config.RefreshAhead >= config.TTL {
+		config.RefreshAhead = min(defaultRefreshAhead, config.TTL/2)
+	}
+	c := &CachedClient{
+		client:       client,
+		ttl:          config.TTL,
+		refreshAhead: config.RefreshAhead,
+		fleetStore:   config.Store,
+		now:          time.Now,
+	}
+	c.warm()
+	return c
+}
+
+// GetStarships returns the cached fleet when it is still fresh, otherwise it fetches
+// a new one from the wrapped client. The returned slice is shared and must not be modified.
+func (c *CachedClient) GetStarships(ctx context.Context) ([]domain.Starship, error) {
+	c.mu.Lock()
+	starships, source, fetchedAt, cached := c.starships, c.source, c.fetchedAt, c.cached
+	if cached {
+		age := c.now().Sub(fetchedAt)
+		if age < c.ttl {
+			if age >= c.ttl-c.refreshAhead && !c.refreshing {
+				c.refreshing = true
+				go c.refresh()
+			}
+			c.mu.Unlock()
+			c.hits.Add(1)
+			RecordSource(ctx, source)
+			return starships, nil
+		}
+	}
+	c.mu.Unlock()
+
+	c.misses.Add(1)
+	fetchCtx, recorder := WithSourceRecorder(ctx)
+	fresh, err := c.client.GetStarships(fetchCtx)
+	if err != nil {
+		if ctx.Err() != nil || !cached {
+			return nil, err
+		}
+		c.refreshErrors.Add(1)
+		c.staleServed.Add(1)
+		log.Printf("Warning: serving stale starships fetched at %s: %v", fetchedAt.Format(time.RFC3339), err)
+		RecordSource(ctx, source)
+		return starships, nil
+	}
+
+	c.store(fresh, recorder.Source())
+	RecordSource(ctx, recorder.Source())
+	return fresh, nil
+}
+
+// Stats returns a snapshot of the cache counters
+func (c *CachedClient) Stats() CacheStats {
+	return CacheStats{
+		Hits:          c.hits.Load(),
+		Misses:        c.misses.Load(),
+		StaleServed:   c.staleServed.Load(),
+		RefreshErrors: c.refreshErrors.Load(),
+	}
+}
+
+// refresh fetches a new fleet in the background, keeping the current one on failure
+func (c *CachedClient) refresh() {
+	defer func() {
+		c.mu.Lock()
+		c.refreshing = false
+		c.mu.Unlock()
+	}()
+
+	ctx, recorder := WithSourceRecorder(context.Background())
+	fresh, err := c.client.GetStarships(ctx)
+	if err != nil {
+		c.refreshErrors.Add(1)
+		log.Printf("Warning: background starship refresh failed: %v", err)
+		return
+	}
+	c.store(fresh, recorder.Source())
+}
+
+// warm loads the persisted fleet, if any, and starts a background refresh when it is due
+func (c *CachedClient) warm() {
+	if c.fleetStore == nil {
+		return
+	}
+
+	fleet, err := c.fleetStore.Load()
+	if err != nil {
+		if !errors.Is(err, ErrNoStoredFleet) {
+			log.Printf("Warning: could not load stored starships: %v", err)
+		}
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.starships = fleet.Starships
+	c.source = fleet.Source
+	c.fetchedAt = fleet.FetchedAt
+	c.cached = true
+	c.savedAt = fleet.FetchedAt
+	log.Printf("Loaded %d stored starships fetched at %s", len(fleet.Starships), fleet.FetchedAt.Format(time.RFC3339))
+
+	if c.now().Sub(fleet.FetchedAt) >= c.ttl-c.refreshAhead {
+		c.refreshing = true
+		go c.refresh()
+	}
+}
+
+// store replaces the cached fleet, resets its age and persists it when a store is configured.
+// Saves are serialized and skipped when a newer fleet was persisted meanwhile, so that
+// concurrent refreshes can't leave an older fleet on disk.
+func (c *CachedClient) store(starships []domain.Starship, source string) {
+	c.mu.Lock()
+	fetchedAt := c.now()
+	c.starships = starships
+	c.source = source
+	c.fetchedAt = fetchedAt
+	c.cached = true
+	c.mu.Unlock()
+
+	if c.fleetStore == nil {
+		return
+	}
+	c.saveMu.Lock()
+	defer c.saveMu.Unlock()
+	if !fetchedAt.After(c.savedAt) {
+		return
+	}
+	c.savedAt = fetchedAt
+	err := c.fleetStore.Save(StoredFleet{Starships: starships, Source: source, FetchedAt: fetchedAt})
+	if err != nil {
+		log.Printf("Warning: could not persist starships: %v", err)
+	}
+}
@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pvdevs/get-starships-stops/internal/domain"
+)
+
+// countingClient is a StarshipClient that counts calls and can be switched to fail
+type countingClient struct {
+	mu        sync.Mutex
+	calls     int
+	starships []domain.Starship
+	err       error
+	fetched   chan struct{} // Signalled after every call, if set
+}
+
+func (m *countingClient) GetStarships(ctx context.Context) ([]domain.Starship, error) {
+	m.mu.Lock()
+	m.calls++
+	starships, err := m.starships, m.err
+	m.mu.Unlock()
+	if m.fetched != nil {
+		m.fetched <- struct{}{}
+	}
+	return starships, err
+}
+
+func (m *countingClient) set(starships []domain.Starship, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.starships, m.err = starships, err
+}
+
+func (m *countingClient) callCount() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.calls
+}
+
+// TestCachedClient verifies the caching policy of CachedClient.
+// It tests scenarios including:
+// - Fresh fleets served without calling the upstream
+// - Expired fleets refetched synchronously
+// - Stale fleets served when the upstream fails
+// - Errors returned when nothing has been cached yet
+func TestCachedClient(t *testing.T) {
+	fleet := []domain.Starship{{Name: "X-wing", MGLT: 100, Consumables: "1 week"}}
+	upstreamErr := errors.New("upstream down")
+
+	tests := []struct {
+		name      string        // Test case description
+		primed    bool          // Whether the cache is filled before the call
+		age       time.Duration // How old the cached fleet is at call time
+		err       error         // Error returned by the upstream on the call
+		wantErr   bool          // Whether an error is expected
+		wantCalls int           // Expected total upstream calls
+		wantStats CacheStats    // Expected counters after the call
+	}{
+		{
+			name:      "fresh fleet is a hit",
+			primed:    true,
+			age:       time.Minute,
+			wantCalls: 1,
+			wantStats: CacheStats{Hits: 1, Misses: 1},
+		},
+		{
+			name:      "expired fleet is refetched",
+			primed:    true,
+			age:       10 * time.Minute,
+			wantCalls: 2,
+			wantStats: CacheStats{Misses: 2},
+		},
+		{
+			name:      "stale fleet served on upstream failure",
+			primed:    true,
+			age:       10 * time.Minute,
+			err:       upstreamErr,
+			wantCalls: 2,
+			wantStats: CacheStats{Misses: 2, StaleServed: 1, RefreshErrors: 1},
+		},
+		{
+			name:      "error without cached fleet",
+			err:       upstreamErr,
+			wantErr:   true,
+			wantCalls: 1,
+			wantStats: CacheStats{Misses: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			upstream := &countingClient{starships: fleet}
+			now := time.Now()
+			cache := NewCachedClient(upstream, CacheConfig{TTL: 5 * time.Minute, RefreshAhead: time.Second})
+			cache.now = func() time.Time { return now }
+
+			if tt.primed {
+				if _, err := cache.GetStarships(context.Background()); err != nil {
+					t.Fatalf("priming GetStarships() error = %v", err)
+				}
+			}
+
+			now = now.Add(tt.age)
+			upstream.set(fleet, tt.err)
+			got, err := cache.GetStarships(context.Background())
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetStarships() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && len(got) != len(fleet) {
+				t.Errorf("GetStarships() got %d starships, want %d", len(got), len(fleet))
+			}
+			if calls := upstream.callCount(); calls != tt.wantCalls {
+				t.Errorf("expected %d upstream calls, got %d", tt.wantCalls, calls)
+			}
+			if stats := cache.Stats(); stats != tt.wantStats {
+				t.Errorf("Stats() = %+v, want %+v", stats, tt.wantStats)
+			}
+		})
+	}
+}
+
+// TestCachedClient_backgroundRefresh verifies that a fleet close to expiry is served
+// immediately while a new one is fetched in the background.
+func TestCachedClient_backgroundRefresh(t *testing.T) {
+	upstream := &countingClient{
+		starships: []domain.Starship{{Name: "X-wing", MGLT: 100, Consumables: "1 week"}},
+		fetched:   make(chan struct{}, 2),
+	}
+	now := time.Now()
+	cache := NewCachedClient(upstream, CacheConfig{TTL: 5 * time.Minute, RefreshAhead: time.Minute})
+	cache.now = func() time.Time { return now }
+
+	if _, err := cache.GetStarships(context.Background()); err != nil {
+		t.Fatalf("priming GetStarships() error = %v", err)
+	}
+	<-upstream.fetched
+
+	now = now.Add(4*time.Minute + 30*time.Second)
+	upstream.set([]domain.Starship{{Name: "Y-wing", MGLT: 80, Consumables: "1 week"}}, nil)
+
+	got, err := cache.GetStarships(context.Background())
+	if err != nil {
+		t.Fatalf("GetStarships() error = %v", err)
+	}
+	if got[0].Name != "X-wing" {
+		t.Errorf("expected cached X-wing to be served during refresh, got %s", got[0].Name)
+	}
+
+	select {
+	case <-upstream.fetched:
+	case <-time.After(time.Second):
+		t.Fatal("background refresh was not started")
+	}
+
+	// Wait for the refreshed fleet to be stored
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		got, _ = cache.GetStarships(context.Background())
+		if got[0].Name == "Y-wing" {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Errorf("expected refreshed Y-wing after background refresh, got %s", got[0].Name)
+}
@@ -2,12 +2,30 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math"
 
 	"github.com/pvdevs/get-starships-stops/internal/domain"
 	"github.com/pvdevs/get-starships-stops/internal/parser"
 )
 
+var (
+	ErrUnknownMGLT = errors.New("unknown MGLT")
+	ErrZeroSpeed   = errors.New("MGLT must be positive")
+	ErrOverflow    = errors.New("result is too large to represent")
+)
+
+// Reasons a starship is left out of stop calculations
+const (
+	SkipUnknownMGLT        = "unknown_mglt"
+	SkipInvalidConsumables = "invalid_consumables"
+	SkipZeroSpeed          = "zero_speed"
+	SkipUnknownHyperdrive  = "unknown_hyperdrive"
+	SkipReserveTooLarge    = "reserve_exceeds_autonomy"
+	SkipStrategyError      = "strategy_error"
+)
+
 // StarshipClient defines the interface for fetching starship data
 // This allows to easily mock the client in tests
 type StarshipClient interface {
@@ -16,7 +34,42 @@ type StarshipClient interface {
 
 // CalculatorService defines the interface for calculating starship stops
 type CalculatorService interface {
-	CalculateStops(ctx context.Context, distance int64) (map[string]int, error)
+	CalculateStops(ctx context.Context, distance int64, opts Options) (Calculation, error)
+	CalculateStopsBatch(ctx context.Context, distances []int64, opts Options) ([]Calculation, error)
+	MaxRanges(ctx context.Context, stops int64, opts Options) (RangeCalculation, error)
+}
+
+// Options tune a stop calculation
+type Options struct {
+	DwellHours int64               // Hours spent resupplying at every stop
+	Strategy   StopStrategy        // Rule counting the stops, the exact rule of StopsForShip when nil
+	Reserve    Reserve             // Consumables kept unused at every stop, applied by the calculators and recommenders
+	Overrides  map[string]Override // Starship values replaced for this calculation, keyed by SWAPI id or name
+}
+
+// stops counts the stops of a starship with the strategy of the options
+func (o Options) stops(ship domain.Starship, distance int64) (int64, error) {
+	if o.Strategy == nil {
+		return StopsForShip(ship, distance)
+	}
+	return o.Strategy.Stops(ship, distance)
+}
+
+// Calculation holds the stops of every starship for a distance, and the starships
+// left out because their data can't be used
+type Calculation struct {
+	Stops        map[string]int64    // Starship names to their required number of stops
+	Travel       map[string]Travel   // Starship names to their travel time, missing when it can't be computed
+	TravelErrors map[string]error    // Starship names to why their travel time is missing, such as ErrOverflow
+	Skipped      []SkippedStarship   // Starships left out of Stops, with the reason why
+	Overridden   map[string][]string // Starship names to the Override* values replaced by the options
+}
+
+// SkippedStarship is a starship left out of stop calculations, with the reason why
+type SkippedStarship struct {
+	Starship domain.Starship
+	Reason   string // One of the Skip* reasons
+	Detail   string // Human readable explanation
 }
 
 // Calculator handles the business logic for calculating required stops
@@ -32,34 +85,159 @@ func NewCalculator(client StarshipClient) *Calculator {
 }
 
 // CalculateStops determines how many stops each starship needs to make for a given distance
-// It returns a map of starship names to their required number of stops
-func (c *Calculator) CalculateStops(ctx context.Context, distance int64) (map[string]int, error) {
+// It returns the stops per starship name, and the starships that were skipped
+func (c *Calculator) CalculateStops(ctx context.Context, distance int64, opts Options) (Calculation, error) {
+	starships, err := c.client.GetStarships(ctx)
+	if err != nil {
+		return Calculation{}, fmt.Errorf("fetch starships: %w", err)
+	}
+
+	starships, overridden, err := applyOverrides(starships, opts.Overrides)
+	if err != nil {
+		return Calculation{}, err
+	}
+	fleet, skipped := reserveFleet(starships, opts.Reserve)
+	result := stopsForFleet(fleet, distance, opts)
+	result.Skipped = append(skipped, result.Skipped...)
+	result.Overridden = overridden
+	return result, nil
+}
+
+// CalculateStopsBatch determines the stops of each starship for several distances,
+// fetching the fleet only once. Results are returned in the order of distances.
+func (c *Calculator) CalculateStopsBatch(ctx context.Context, distances []int64, opts Options) ([]Calculation, error) {
 	starships, err := c.client.GetStarships(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("fetch starships: %w", err)
 	}
 
-	results := make(map[string]int)
+	starships, overridden, err := applyOverrides(starships, opts.Overrides)
+	if err != nil {
+		return nil, err
+	}
+	fleet, skipped := reserveFleet(starships, opts.Reserve)
+	results := make([]Calculation, len(distances))
+	for i, distance := range distances {
+		results[i] = stopsForFleet(fleet, distance, opts)
+		results[i].Skipped = append(skipped[:len(skipped):len(skipped)], results[i].Skipped...)
+		results[i].Overridden = overridden
+	}
+	return results, nil
+}
+
+// stopsForFleet computes the stops and travel time of every starship in the fleet for a single distance
+func stopsForFleet(starships []domain.Starship, distance int64, opts Options) Calculation {
+	result := Calculation{
+		Stops:        make(map[string]int64),
+		Travel:       make(map[string]Travel),
+		TravelErrors: make(map[string]error),
+	}
 
 	for _, ship := range starships {
-		if ship.MGLT <= 0 {
-			results[ship.Name] = 0
+		stops, err := opts.stops(ship, distance)
+		if err != nil {
+			result.Skipped = append(result.Skipped, skip(ship, err))
 			continue
 		}
+		result.Stops[ship.Name] = stops
 
-		hours, err := parser.ParseConsumables(ship.Consumables)
+		travel, err := TravelForShip(ship, distance, stops, opts.DwellHours, opts.Strategy)
 		if err != nil {
+			result.TravelErrors[ship.Name] = err
 			continue
 		}
+		result.Travel[ship.Name] = travel
+	}
 
-		maxDistance := ship.MGLT * hours
-		stops := int(distance) / maxDistance
-		if int(distance)%maxDistance == 0 && stops > 0 {
-			stops--
-		}
+	return result
+}
+
+// StopsForShip determines how many stops a single starship needs for a given distance.
+// Returns an error if the starship's speed is unknown or not positive, or if its
+// consumables can't be parsed.
+func StopsForShip(ship domain.Starship, distance int64) (int64, error) {
+	_, maxDistance, err := shipRange(ship)
+	if errors.Is(err, ErrOverflow) {
+		return 0, nil // The range exceeds any int64 distance
+	}
+	if err != nil {
+		return 0, err
+	}
 
-		results[ship.Name] = stops
+	stops := distance / maxDistance
+	if distance%maxDistance == 0 && stops > 0 {
+		stops--
 	}
 
-	return results, nil
+	return stops, nil
+}
+
+// shipRange returns how many hours a starship can travel between two stops, its consumables
+// minus its reserve, and the distance it covers in that time. The hours are still returned
+// along with ErrOverflow when the distance doesn't fit in an int64.
+func shipRange(ship domain.Starship) (hours, maxDistance int64, err error) {
+	if ship.UnknownMGLT {
+		return 0, 0, fmt.Errorf("speed of %s: %w", ship.Name, ErrUnknownMGLT)
+	}
+	if ship.MGLT <= 0 {
+		return 0, 0, fmt.Errorf("speed of %s: %w", ship.Name, ErrZeroSpeed)
+	}
+
+	hours, err = parser.ParseConsumables(ship.Consumables)
+	if err != nil {
+		return 0, 0, fmt.Errorf("parse consumables of %s: %w", ship.Name, err)
+	}
+	if ship.ReserveHours >= hours {
+		return 0, 0, fmt.Errorf("autonomy of %s: %w", ship.Name, ErrReserveTooLarge)
+	}
+	hours -= ship.ReserveHours
+
+	maxDistance, err = mulInt64(int64(ship.MGLT), hours)
+	if err != nil {
+		return hours, 0, fmt.Errorf("range of %s: %w", ship.Name, err)
+	}
+	return hours, maxDistance, nil
+}
+
+// mulInt64 multiplies two non-negative int64 values, failing with ErrOverflow
+// instead of wrapping around
+func mulInt64(a, b int64) (int64, error) {
+	if a != 0 && b > math.MaxInt64/a {
+		return 0, ErrOverflow
+	}
+	return a * b, nil
+}
+
+// SkipReason returns the Skip* reason matching an error returned by StopsForShip, HyperspaceStarship,
+// ReservedStarship or a stop strategy. Errors a strategy returns for its own reasons are reported as SkipStrategyError.
+func SkipReason(err error) string {
+	switch {
+	case errors.Is(err, ErrUnknownMGLT):
+		return SkipUnknownMGLT
+	case errors.Is(err, ErrZeroSpeed):
+		return SkipZeroSpeed
+	case errors.Is(err, ErrUnknownHyperdrive):
+		return SkipUnknownHyperdrive
+	case errors.Is(err, ErrReserveTooLarge):
+		return SkipReserveTooLarge
+	case errors.Is(err, parser.ErrInvalidConsumables), errors.Is(err, parser.ErrEmptyConsumables), errors.Is(err, parser.ErrInputTooLarge):
+		return SkipInvalidConsumables
+	default:
+		return SkipStrategyError
+	}
+}
+
+// skip describes why a starship was left out, from the error returned by StopsForShip,
+// HyperspaceStarship or a stop strategy. The detail drops the starship name prefix when
+// the error wraps another one.
+func skip(ship domain.Starship, err error) SkippedStarship {
+	detail := err.Error()
+	if inner := errors.Unwrap(err); inner != nil {
+		detail = inner.Error()
+	}
+	return SkippedStarship{
+		Starship: ship,
+		Reason:   SkipReason(err),
+		Detail:   detail,
+	}
 }
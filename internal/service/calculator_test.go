@@ -2,9 +2,13 @@ package service
 
 import (
 	"context"
+	"errors"
+	"math"
+	"math/big"
 	"testing"
 
 	"github.com/pvdevs/get-starships-stops/internal/domain"
+	"github.com/pvdevs/get-starships-stops/internal/parser"
 )
 
 // mockStarshipClient implements a mock version of our SWAPI client interface
@@ -22,13 +26,15 @@ func (m *mockStarshipClient) GetStarships(ctx context.Context) ([]domain.Starshi
 // - Multiple starships with different speeds and consumables
 // - Edge cases like MGLT = 0
 // - Long distance calculations
+// - Skipping ships with unknown MGLT or unparseable consumables
 func TestCalculateStops(t *testing.T) {
 	// Define test cases using table-driven test pattern
 	tests := []struct {
 		name          string            // Description of the test case
 		distance      int64             // Input distance to travel
 		starships     []domain.Starship // Mock starships for testing
-		expectedStops map[string]int    // Expected number of stops for each ship
+		expectedStops map[string]int64  // Expected number of stops for each ship
+		wantSkipped   map[string]string // Expected skip reason for each skipped ship
 		wantErr       bool              // Whether we expect an error
 	}{
 		{
@@ -46,7 +52,7 @@ func TestCalculateStops(t *testing.T) {
 					Consumables: "1 week",
 				},
 			},
-			expectedStops: map[string]int{
+			expectedStops: map[string]int64{
 				"Millennium Falcon": 9,
 				"Y-wing":            74,
 			},
@@ -62,8 +68,39 @@ func TestCalculateStops(t *testing.T) {
 					Consumables: "3 years",
 				},
 			},
-			expectedStops: map[string]int{
-				"Death Star": 0,
+			expectedStops: map[string]int64{},
+			wantSkipped: map[string]string{
+				"Death Star": SkipZeroSpeed,
+			},
+			wantErr: false,
+		},
+		{
+			name:     "largest distance without overflow",
+			distance: math.MaxInt64,
+			starships: []domain.Starship{
+				{Name: "X-wing", MGLT: 100, Consumables: "1 week"},
+				{Name: "Eternal", MGLT: 10000, Consumables: "1000000000000 years"},
+			},
+			expectedStops: map[string]int64{
+				"X-wing":  549010240288974, // math.MaxInt64 / 16800
+				"Eternal": 0,               // Range beyond any int64 distance
+			},
+			wantErr: false,
+		},
+		{
+			name:     "skip ships with unusable data",
+			distance: 1000000,
+			starships: []domain.Starship{
+				{Name: "X-wing", MGLT: 100, Consumables: "1 week"},
+				{Name: "Executor", UnknownMGLT: true, Consumables: "6 years"},
+				{Name: "Broken", MGLT: 10, Consumables: "forever"},
+			},
+			expectedStops: map[string]int64{
+				"X-wing": 59,
+			},
+			wantSkipped: map[string]string{
+				"Executor": SkipUnknownMGLT,
+				"Broken":   SkipInvalidConsumables,
 			},
 			wantErr: false,
 		},
@@ -77,7 +114,7 @@ func TestCalculateStops(t *testing.T) {
 					Consumables: "1 week",
 				},
 			},
-			expectedStops: map[string]int{
+			expectedStops: map[string]int64{
 				"X-wing": 595, // Updated expected value based on correct calculation
 			},
 			wantErr: false,
@@ -97,7 +134,8 @@ func TestCalculateStops(t *testing.T) {
 			calculator := NewCalculator(mockClient)
 
 			// Execute the method being tested
-			stops, err := calculator.CalculateStops(context.Background(), tt.distance)
+			calculation, err := calculator.CalculateStops(context.Background(), tt.distance, Options{})
+			stops := calculation.Stops
 
 			// Verify error expectations
 			if (err != nil) != tt.wantErr {
@@ -118,6 +156,108 @@ func TestCalculateStops(t *testing.T) {
 					t.Errorf("For ship %s: expected %d stops, got %d", shipName, expectedStops, gotStops)
 				}
 			}
+
+			// Check each skipped ship's reason
+			if len(calculation.Skipped) != len(tt.wantSkipped) {
+				t.Errorf("Expected %d skipped ships, got %+v", len(tt.wantSkipped), calculation.Skipped)
+			}
+			for _, skipped := range calculation.Skipped {
+				if reason := tt.wantSkipped[skipped.Starship.Name]; reason != skipped.Reason {
+					t.Errorf("For ship %s: expected skip reason %q, got %q", skipped.Starship.Name, reason, skipped.Reason)
+				}
+			}
 		})
 	}
 }
+
+// TestCalculateStopsBatch verifies that a batch fetches the fleet once and returns
+// one result per distance, in order.
+func TestCalculateStopsBatch(t *testing.T) {
+	client := &countingClient{starships: []domain.Starship{
+		{Name: "X-wing", MGLT: 100, Consumables: "1 week"},
+		{Name: "Millennium Falcon", MGLT: 75, Consumables: "2 months"},
+	}}
+	calculator := NewCalculator(client)
+
+	distances := []int64{1000000, 16800, 0}
+	results, err := calculator.CalculateStopsBatch(context.Background(), distances, Options{})
+	if err != nil {
+		t.Fatalf("CalculateStopsBatch() error = %v", err)
+	}
+
+	if calls := client.callCount(); calls != 1 {
+		t.Errorf("expected the fleet to be fetched once, got %d fetches", calls)
+	}
+	if len(results) != len(distances) {
+		t.Fatalf("expected %d results, got %d", len(distances), len(results))
+	}
+
+	expected := []map[string]int64{
+		{"X-wing": 59, "Millennium Falcon": 9},
+		{"X-wing": 0, "Millennium Falcon": 0},
+		{"X-wing": 0, "Millennium Falcon": 0},
+	}
+	for i, want := range expected {
+		for name, stops := range want {
+			if got := results[i].Stops[name]; got != stops {
+				t.Errorf("distance %d, ship %s: expected %d stops, got %d", distances[i], name, stops, got)
+			}
+		}
+	}
+}
+
+// TestCalculateStops_travelOverflow verifies that a starship whose travel time can't be
+// represented keeps its stops, with the reason its travel is missing.
+func TestCalculateStops_travelOverflow(t *testing.T) {
+	calculator := NewCalculator(&mockStarshipClient{starships: []domain.Starship{
+		{Name: "Slow", MGLT: 1, Consumables: "1 day"},
+	}})
+
+	calculation, err := calculator.CalculateStops(context.Background(), math.MaxInt64, Options{DwellHours: 1000})
+	if err != nil {
+		t.Fatalf("CalculateStops() error = %v", err)
+	}
+	if _, ok := calculation.Stops["Slow"]; !ok {
+		t.Fatalf("expected the stops of Slow, got %v", calculation.Stops)
+	}
+	if _, ok := calculation.Travel["Slow"]; ok || !errors.Is(calculation.TravelErrors["Slow"], ErrOverflow) {
+		t.Errorf("expected the travel of Slow to overflow, got %+v and %v", calculation.Travel, calculation.TravelErrors)
+	}
+}
+
+// FuzzStopsForShip checks the stops of any starship against the same rule computed with
+// math/big, for every distance parser.ParseDistance accepts.
+func FuzzStopsForShip(f *testing.F) {
+	f.Add("1000000", 100, "1 week")
+	f.Add("9223372036854775807", 75, "2 months")
+	f.Add("9223372036854775807", 1, "1 day")
+	f.Add("16800", 100, "1 week")
+	f.Add("0", 10, "1 year")
+	f.Add("1000000", 10000, "1000000000000 years")
+
+	f.Fuzz(func(t *testing.T, input string, mglt int, consumables string) {
+		distance, err := parser.ParseDistance(input)
+		if err != nil {
+			return
+		}
+		hours, err := parser.ParseConsumables(consumables)
+		if err != nil || mglt <= 0 {
+			return
+		}
+
+		ship := domain.Starship{Name: "Fuzz", MGLT: mglt, Consumables: consumables}
+		stops, err := StopsForShip(ship, distance)
+		if err != nil {
+			t.Fatalf("StopsForShip(%+v, %d) error = %v", ship, distance, err)
+		}
+
+		bigRange := new(big.Int).Mul(big.NewInt(int64(mglt)), big.NewInt(hours))
+		want, rem := new(big.Int).QuoRem(big.NewInt(distance), bigRange, new(big.Int))
+		if rem.Sign() == 0 && want.Sign() > 0 {
+			want.Sub(want, big.NewInt(1))
+		}
+		if want.Cmp(big.NewInt(stops)) != 0 {
+			t.Fatalf("StopsForShip(%+v, %d) = %d, want %s", ship, distance, stops, want)
+		}
+	})
+}
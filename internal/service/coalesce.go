@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pvdevs/get-starships-stops/internal/domain"
+)
+
+// CoalescingClient wraps a StarshipClient so that concurrent callers share a single
+// in-flight fetch instead of each starting their own upstream crawl
+type CoalescingClient struct {
+	client StarshipClient
+
+	mu       sync.Mutex
+	inflight *fetchCall
+}
+
+// fetchCall tracks one shared fetch and the callers waiting for it
+type fetchCall struct {
+	done      chan struct{}
+	starships []domain.Starship
+	source    string
+	err       error
+	waiters   int
+	cancel    context.CancelFunc
+}
+
+// NewCoalescingClient creates a new coalescing wrapper around the provided client
+func NewCoalescingClient(client StarshipClient) *CoalescingClient {
+	return &CoalescingClient{
+		client: client,
+	}
+}
+
+// GetStarships joins the in-flight fetch if there is one, otherwise it starts a new one.
+// Cancelling ctx only abandons this caller; the shared fetch is cancelled once every
+// caller waiting on it has gone away.
+func (c *CoalescingClient) GetStarships(ctx context.Context) ([]domain.Starship, error) {
+	c.mu.Lock()
+	call := c.inflight
+	if call == nil {
+		fetchCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		call = &fetchCall{
+			done:   make(chan struct{}),
+			cancel: cancel,
+		}
+		c.inflight = call
+		go c.fetch(fetchCtx, call)
+	}
+	call.waiters++
+	c.mu.Unlock()
+
+	select {
+	case <-call.done:
+		RecordSource(ctx, call.source)
+		return call.starships, call.err
+	case <-ctx.Done():
+		c.mu.Lock()
+		call.waiters--
+		if call.waiters == 0 {
+			call.cancel()
+			if c.inflight == call {
+				c.inflight = nil
+			}
+		}
+		c.mu.Unlock()
+		return nil, ctx.Err()
+	}
+}
+
+// waiting returns how many callers wait on the in-flight fetch, 0 when there is none
+func (c *CoalescingClient) waiting() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.inflight == nil {
+		return 0
+	}
+	return c.inflight.waiters
+}
+
+// fetch runs the shared upstream call and releases everyone waiting on it
+func (c *CoalescingClient) fetch(ctx context.Context, call *fetchCall) {
+	defer call.cancel()
+
+	ctx, recorder := WithSourceRecorder(ctx)
+	call.starships, call.err = c.client.GetStarships(ctx)
+	call.source = recorder.Source()
+
+	c.mu.Lock()
+	if c.inflight == call {
+		c.inflight = nil
+	}
+	c.mu.Unlock()
+	close(call.done)
+}
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pvdevs/get-starships-stops/internal/domain"
+)
+
+// blockingClient is a StarshipClient that blocks every fetch until released
+type blockingClient struct {
+	calls     atomic.Int32
+	started   chan struct{}
+	release   chan struct{}
+	cancelled chan struct{}
+	starships []domain.Starship
+}
+
+func (m *blockingClient) GetStarships(ctx context.Context) ([]domain.Starship, error) {
+	m.calls.Add(1)
+	m.started <- struct{}{}
+	select {
+	case <-m.release:
+		return m.starships, nil
+	case <-ctx.Done():
+		close(m.cancelled)
+		return nil, ctx.Err()
+	}
+}
+
+func newBlockingClient() *blockingClient {
+	return &blockingClient{
+		started:   make(chan struct{}, 1),
+		release:   make(chan struct{}),
+		cancelled: make(chan struct{}),
+		starships: []domain.Starship{{Name: "X-wing", MGLT: 100, Consumables: "1 week"}},
+	}
+}
+
+// TestCoalescingClient_sharesFetch verifies that concurrent callers produce a single
+// upstream fetch and all receive its result, even when one of them cancels.
+func TestCoalescingClient_sharesFetch(t *testing.T) {
+	upstream := newBlockingClient()
+	client := NewCoalescingClient(upstream)
+
+	// Start the shared fetch with a caller that gives up early
+	cancelCtx, cancel := context.WithCancel(context.Background())
+	cancelledErr := make(chan error, 1)
+	go func() {
+		_, err := client.GetStarships(cancelCtx)
+		cancelledErr <- err
+	}()
+	<-upstream.started
+
+	const callers = 50
+	var wg sync.WaitGroup
+	errs := make(chan error, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			starships, err := client.GetStarships(context.Background())
+			if err == nil && len(starships) != 1 {
+				err = errors.New("unexpected starships")
+			}
+			errs <- err
+		}()
+	}
+
+	// Wait for every caller to join before cancelling the first one
+	deadline := time.Now().Add(5 * time.Second)
+	for client.waiting() < callers+1 {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected %d callers to join the fetch, got %d", callers+1, client.waiting())
+		}
+		runtime.Gosched()
+	}
+	cancel()
+	if err := <-cancelledErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected cancelled caller to get context.Canceled, got %v", err)
+	}
+
+	close(upstream.release)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Errorf("GetStarships() error = %v", err)
+		}
+	}
+	if calls := upstream.calls.Load(); calls != 1 {
+		t.Errorf("expected 1 upstream fetch, got %d", calls)
+	}
+}
+
+// TestCoalescingClient_cancelsAbandonedFetch verifies that the shared fetch is cancelled
+// once every caller waiting on it has cancelled.
+func TestCoalescingClient_cancelsAbandonedFetch(t *testing.T) {
+	upstream := newBlockingClient()
+	client := NewCoalescingClient(upstream)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		_, err := client.GetStarships(ctx)
+		done <- err
+	}()
+	<-upstream.started
+	cancel()
+
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	select {
+	case <-upstream.cancelled:
+	case <-time.After(time.Second):
+		t.Fatal("shared fetch was not cancelled after all callers left")
+	}
+}
@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pvdevs/get-starships-stops/internal/domain"
+)
+
+const (
+	defaultSourceTimeout    = 10 * time.Second
+	defaultFailureThreshold = 3
+	defaultSourceCooldown   = 30 * time.Second
+)
+
+// FallbackSource is one entry of a fallback chain
+type FallbackSource struct {
+	Name    string         // Name reported when this source answers
+	Client  StarshipClient // Client fetching from this source
+	Timeout time.Duration  // Maximum time a single fetch from this source may take
+}
+
+// FallbackConfig controls when a failing source is considered unhealthy
+type FallbackConfig struct {
+	FailureThreshold int           // Consecutive failures after which a source is skipped
+	Cooldown         time.Duration // How long an unhealthy source is skipped before being tried again
+}
+
+// SourceHealth describes the health of a source in the fallback chain
+type SourceHealth struct {
+	Name      string    `json:"name"`
+	Healthy   bool      `json:"healthy"`
+	Failures  int       `json:"consecutive_failures"`
+	LastError string    `json:"last_error,omitempty"`
+	DownUntil time.Time `json:"down_until"`
+	Breaker   string    `json:"breaker,omitempty"` // Circuit breaker state, for sources guarded by one
+}
+
+// BreakerReporter is implemented by source clients guarded by a circuit breaker, such as
+// swapi.Client, so that the breaker state shows in their SourceHealth
+type BreakerReporter interface {
+	BreakerStatus() string
+}
+
+// FallbackClient tries a list of sources in order and returns the first fleet fetched
+// successfully. Sources that keep failing are skipped for a while, so a flaky primary
+// stops costing its full timeout on every request.
+type FallbackClient struct {
+	sources          []FallbackSource
+	failureThreshold int
+	cooldown         time.Duration
+	now              func() time.Time
+
+	mu     sync.Mutex
+	health []SourceHealth
+}
+
+// NewFallbackClient creates a fallback chain over the given sources, tried in order
+func NewFallbackClient(sources []FallbackSource, config FallbackConfig) *FallbackClient {
+	if config.FailureThreshold <= 0 {
+		config.FailureThreshold = defaultFailureThreshold
+	}
+	if config.Cooldown <= 0 {
+		config.Cooldown = defaultSourceCooldown
+	}
+
+	health := make([]SourceHealth, len(sources))
+	for i := range sources {
+		if sources[i].Timeout <= 0 {
+			sources[i].Timeout = defaultSourceTimeout
+		}
+		health[i] = SourceHealth{Name: sources[i].Name, Healthy: true}
+	}
+
+	return &FallbackClient{
+		sources:          sources,
+		failureThreshold: config.FailureThreshold,
+		cooldown:         config.Cooldown,
+		now:              time.Now,
+		health:           health,
+	}
+}
+
+// GetStarships fetches from the first healthy source that answers, falling back to
+// unhealthy ones only when every healthy source has failed. The answering source is
+// reported through RecordSource.
+func (c *FallbackClient) GetStarships(ctx context.Context) ([]domain.Starship, error) {
+	var errs []error
+	for _, i := range c.order() {
+		source := c.sources[i]
+
+		sourceCtx, cancel := context.WithTimeout(ctx, source.Timeout)
+		starships, err := source.Client.GetStarships(sourceCtx)
+		cancel()
+
+		if err == nil {
+			c.recordSuccess(i)
+			RecordSource(ctx, source.Name)
+			return starships, nil
+		}
+		if ctx.Err() != nil {
+			return nil, err
+		}
+
+		c.recordFailure(i, err)
+		errs = append(errs, fmt.Errorf("%s: %w", source.Name, err))
+	}
+
+	return nil, fmt.Errorf("all starship sources failed: %w", errors.Join(errs...))
+}
+
+// Health returns the current health of every source, in chain order, with the breaker
+// state of the sources implementing BreakerReporter
+func (c *FallbackClient) Health() []SourceHealth {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	health := make([]SourceHealth, len(c.health))
+	for i, h := range c.health {
+		h.Healthy = !now.Before(h.DownUntil)
+		if reporter, ok := c.sources[i].Client.(BreakerReporter); ok {
+			h.Breaker = reporter.BreakerStatus()
+		}
+		health[i] = h
+	}
+	return health
+}
+
+// order returns source indexes to try: healthy sources first, then unhealthy ones,
+// each group keeping the configured order
+func (c *FallbackClient) order() []int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	healthy := make([]int, 0, len(c.sources))
+	var unhealthy []int
+	for i, h := range c.health {
+		if now.Before(h.DownUntil) {
+			unhealthy = append(unhealthy, i)
+		} else {
+			healthy = append(healthy, i)
+		}
+	}
+	return append(healthy, unhealthy...)
+}
+
+// recordSuccess marks a source healthy again
+func (c *FallbackClient) recordSuccess(i int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.health[i] = SourceHealth{Name: c.sources[i].Name, Healthy: true}
+}
+
+// recordFailure counts a failure and takes the source out of rotation once it
+// reaches the failure threshold
+func (c *FallbackClient) recordFailure(i int, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	h := &c.health[i]
+	h.Failures++
+	h.LastError = err.Error()
+	if h.Failures >= c.failureThreshold {
+		h.Healthy = false
+		h.DownUntil = c.now().Add(c.cooldown)
+	}
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pvdevs/get-starships-stops/internal/domain"
+)
+
+// slowClient is a StarshipClient that never answers before its context ends
+type slowClient struct{}
+
+func (slowClient) GetStarships(ctx context.Context) ([]domain.Starship, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+// TestFallbackClient verifies that sources are tried in order until one answers.
+// It tests scenarios including:
+// - The primary answering
+// - Falling back when the primary fails or times out
+// - Every source failing
+func TestFallbackClient(t *testing.T) {
+	fleet := []domain.Starship{{Name: "X-wing", MGLT: 100, Consumables: "1 week"}}
+	down := &mockStarshipClient{err: errors.New("upstream down")}
+	up := &mockStarshipClient{starships: fleet}
+
+	tests := []struct {
+		name       string           // Test case description
+		sources    []FallbackSource // Chain of sources
+		wantErr    bool             // Whether an error is expected
+		wantSource string           // Expected answering source
+	}{
+		{
+			name:       "primary answers",
+			sources:    []FallbackSource{{Name: "primary", Client: up}, {Name: "mirror", Client: up}},
+			wantSource: "primary",
+		},
+		{
+			name:       "falls back on error",
+			sources:    []FallbackSource{{Name: "primary", Client: down}, {Name: "mirror", Client: up}},
+			wantSource: "mirror",
+		},
+		{
+			name: "falls back on timeout",
+			sources: []FallbackSource{
+				{Name: "primary", Client: slowClient{}, Timeout: 10 * time.Millisecond},
+				{Name: "snapshot", Client: up},
+			},
+			wantSource: "snapshot",
+		},
+		{
+			name:    "all sources fail",
+			sources: []FallbackSource{{Name: "primary", Client: down}, {Name: "mirror", Client: down}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewFallbackClient(tt.sources, FallbackConfig{})
+			ctx, recorder := WithSourceRecorder(context.Background())
+
+			starships, err := client.GetStarships(ctx)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetStarships() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if len(starships) != len(fleet) {
+				t.Errorf("expected %d starships, got %d", len(fleet), len(starships))
+			}
+			if got := recorder.Source(); got != tt.wantSource {
+				t.Errorf("expected source %q, got %q", tt.wantSource, got)
+			}
+		})
+	}
+}
+
+// TestFallbackClient_health verifies that a source failing repeatedly is skipped
+// until its cooldown passes, and that the answering source survives caching.
+func TestFallbackClient_health(t *testing.T) {
+	primary := &countingClient{err: errors.New("upstream down")}
+	mirror := &countingClient{starships: []domain.Starship{{Name: "X-wing", MGLT: 100, Consumables: "1 week"}}}
+
+	now := time.Now()
+	client := NewFallbackClient([]FallbackSource{
+		{Name: "primary", Client: primary},
+		{Name: "mirror", Client: mirror},
+	}, FallbackConfig{FailureThreshold: 2, Cooldown: time.Minute})
+	client.now = func() time.Time { return now }
+
+	for i := 0; i < 4; i++ {
+		if _, err := client.GetStarships(context.Background()); err != nil {
+			t.Fatalf("GetStarships() error = %v", err)
+		}
+	}
+	if calls := primary.callCount(); calls != 2 {
+		t.Errorf("expected unhealthy primary to be skipped after 2 calls, got %d calls", calls)
+	}
+	if health := client.Health(); health[0].Healthy || !health[1].Healthy {
+		t.Errorf("unexpected health %+v", health)
+	}
+
+	now = now.Add(time.Minute)
+	primary.set([]domain.Starship{{Name: "Y-wing", MGLT: 80, Consumables: "1 week"}}, nil)
+
+	cache := NewCachedClient(client, CacheConfig{TTL: time.Hour})
+	for i := 0; i < 2; i++ {
+		ctx, recorder := WithSourceRecorder(context.Background())
+		if _, err := cache.GetStarships(ctx); err != nil {
+			t.Fatalf("GetStarships() error = %v", err)
+		}
+		if got := recorder.Source(); got != "primary" {
+			t.Errorf("call %d: expected recovered primary to answer, got %q", i, got)
+		}
+	}
+}
@@ -0,0 +1,201 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/pvdevs/get-starships-stops/internal/domain"
+)
+
+var (
+	ErrStarshipNotFound = errors.New("starship not found")
+)
+
+const (
+	maxSuggestions = 5
+)
+
+// NotFoundError is returned when no starship matches a lookup key.
+// It matches ErrStarshipNotFound and carries the closest starship names.
+type NotFoundError struct {
+	Key         string   // Id or name that was looked up
+	Suggestions []string // Names of starships close to the key
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("starship %q not found", e.Key)
+}
+
+// Is makes errors.Is(err, ErrStarshipNotFound) match a NotFoundError
+func (e *NotFoundError) Is(target error) bool {
+	return target == ErrStarshipNotFound
+}
+
+// Capability describes how far a starship can travel between two stops
+type Capability struct {
+	Starship      domain.Starship
+	AutonomyHours int64  // Hours of travel the consumables last
+	MaxRange      *int64 // MGLT covered between two stops (MGLT × autonomy hours), nil when it overflows an int64
+}
+
+// FleetAudit holds the parsed capability of every starship the calculator uses,
+// and the starships it skips
+type FleetAudit struct {
+	Starships []Capability
+	Skipped   []SkippedStarship
+}
+
+// FleetService defines the interface for looking up starships
+type FleetService interface {
+	Starship(ctx context.Context, key string) (domain.Starship, error)
+	Audit(ctx context.Context) (FleetAudit, error)
+}
+
+// Fleet gives access to individual starships of the fleet
+type Fleet struct {
+	client StarshipClient
+}
+
+// NewFleet creates a new instance of Fleet with the provided client
+func NewFleet(client StarshipClient) *Fleet {
+	return &Fleet{
+		client: client,
+	}
+}
+
+// Starship returns the starship matching a SWAPI id or a case-insensitive name
+func (f *Fleet) Starship(ctx context.Context, key string) (domain.Starship, error) {
+	starships, err := f.client.GetStarships(ctx)
+	if err != nil {
+		return domain.Starship{}, fmt.Errorf("fetch starships: %w", err)
+	}
+	return FindStarship(starships, key)
+}
+
+// Audit returns the parsed capability of every starship of the fleet
+func (f *Fleet) Audit(ctx context.Context) (FleetAudit, error) {
+	starships, err := f.client.GetStarships(ctx)
+	if err != nil {
+		return FleetAudit{}, fmt.Errorf("fetch starships: %w", err)
+	}
+	return AuditStarships(starships), nil
+}
+
+// AuditStarships parses the autonomy of every starship, sorted by name, and reports
+// the starships whose data the calculator cannot use
+func AuditStarships(starships []domain.Starship) FleetAudit {
+	var audit FleetAudit
+	for _, ship := range starships {
+		hours, maxRange, err := shipRange(ship)
+		capability := Capability{Starship: ship, AutonomyHours: hours}
+		switch {
+		case errors.Is(err, ErrOverflow):
+		case err != nil:
+			audit.Skipped = append(audit.Skipped, skip(ship, err))
+			continue
+		default:
+			capability.MaxRange = &maxRange
+		}
+		audit.Starships = append(audit.Starships, capability)
+	}
+
+	slices.SortFunc(audit.Starships, func(a, b Capability) int {
+		return strings.Compare(a.Starship.Name, b.Starship.Name)
+	})
+	slices.SortFunc(audit.Skipped, func(a, b SkippedStarship) int {
+		return strings.Compare(a.Starship.Name, b.Starship.Name)
+	})
+	return audit
+}
+
+// FindStarship looks up a starship by SWAPI id or case-insensitive name.
+// When nothing matches, it returns a NotFoundError suggesting similar names.
+func FindStarship(starships []domain.Starship, key string) (domain.Starship, error) {
+	if i := starshipIndex(starships, key); i >= 0 {
+		return starships[i], nil
+	}
+
+	key = strings.TrimSpace(key)
+	return domain.Starship{}, &NotFoundError{
+		Key:         key,
+		Suggestions: suggestNames(starships, key),
+	}
+}
+
+// starshipIndex returns the index of the starship matching a SWAPI id, or else a
+// case-insensitive name, and -1 when none does
+func starshipIndex(starships []domain.Starship, key string) int {
+	key = strings.TrimSpace(key)
+	for i, ship := range starships {
+		if ship.ID != "" && ship.ID == key {
+			return i
+		}
+	}
+	for i, ship := range starships {
+		if strings.EqualFold(ship.Name, key) {
+			return i
+		}
+	}
+	return -1
+}
+
+// suggestNames returns the starship names closest to key: names containing it,
+// contained in it, or within a few edits of it, closest first
+func suggestNames(starships []domain.Starship, key string) []string {
+	type candidate struct {
+		name     string
+		distance int
+	}
+
+	lowerKey := strings.ToLower(key)
+	maxDistance := max(2, len(lowerKey)/3)
+
+	var candidates []candidate
+	for _, ship := range starships {
+		lowerName := strings.ToLower(ship.Name)
+		distance := levenshtein(lowerKey, lowerName)
+		if distance <= maxDistance ||
+			(lowerKey != "" && (strings.Contains(lowerName, lowerKey) || strings.Contains(lowerKey, lowerName))) {
+			candidates = append(candidates, candidate{name: ship.Name, distance: distance})
+		}
+	}
+
+	slices.SortFunc(candidates, func(a, b candidate) int {
+		if a.distance != b.distance {
+			return a.distance - b.distance
+		}
+		return strings.Compare(a.name, b.name)
+	})
+
+	suggestions := make([]string, 0, min(len(candidates), maxSuggestions))
+	for _, c := range candidates[:min(len(candidates), maxSuggestions)] {
+		suggestions = append(suggestions, c.name)
+	}
+	return suggestions
+}
+
+// levenshtein returns the number of single-rune edits needed to turn a into b
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/pvdevs/get-starships-stops/internal/domain"
+)
+
+// TestFindStarship verifies looking up a single starship of the fleet.
+// It tests scenarios including:
+// - Lookups by SWAPI id and by case-insensitive name
+// - Unknown keys, with and without close name matches
+func TestFindStarship(t *testing.T) {
+	fleet := []domain.Starship{
+		{ID: "10", Name: "Millennium Falcon", MGLT: 75, Consumables: "2 months"},
+		{ID: "12", Name: "X-wing", MGLT: 100, Consumables: "1 week"},
+		{ID: "11", Name: "Y-wing", MGLT: 80, Consumables: "1 week"},
+	}
+
+	tests := []struct {
+		name            string   // Test case description
+		key             string   // Id or name to look up
+		wantName        string   // Expected starship name, empty when not found
+		wantSuggestions []string // Expected suggestions when not found
+	}{
+		{
+			name:     "by id",
+			key:      "12",
+			wantName: "X-wing",
+		},
+		{
+			name:     "by name ignoring case",
+			key:      "millennium FALCON",
+			wantName: "Millennium Falcon",
+		},
+		{
+			name:            "typo suggests close names",
+			key:             "X-wnig",
+			wantSuggestions: []string{"X-wing"},
+		},
+		{
+			name:            "partial name suggests containing names",
+			key:             "falcon",
+			wantSuggestions: []string{"Millennium Falcon"},
+		},
+		{
+			name:            "nothing close",
+			key:             "Death Star",
+			wantSuggestions: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ship, err := FindStarship(fleet, tt.key)
+			if tt.wantName != "" {
+				if err != nil {
+					t.Fatalf("FindStarship() error = %v", err)
+				}
+				if ship.Name != tt.wantName {
+					t.Errorf("expected starship %s, got %s", tt.wantName, ship.Name)
+				}
+				return
+			}
+
+			if !errors.Is(err, ErrStarshipNotFound) {
+				t.Fatalf("expected ErrStarshipNotFound, got %v", err)
+			}
+			var notFound *NotFoundError
+			if !errors.As(err, &notFound) {
+				t.Fatalf("expected a NotFoundError, got %T", err)
+			}
+			if !slices.Equal(notFound.Suggestions, tt.wantSuggestions) {
+				t.Errorf("expected suggestions %v, got %v", tt.wantSuggestions, notFound.Suggestions)
+			}
+		})
+	}
+}
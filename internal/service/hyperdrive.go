@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/pvdevs/get-starships-stops/internal/domain"
+)
+
+var (
+	ErrUnknownHyperdrive = errors.New("unknown hyperdrive rating")
+)
+
+// HyperdriveCalculator is a CalculatorService estimating hyperspace travel instead of
+// sublight travel. A starship's hyperspace speed is its MGLT divided by its hyperdrive
+// rating, so a class 0.5 hyperdrive covers twice the distance of a class 1 in the same
+// time and needs fewer stops. Autonomy still comes from the consumables.
+type HyperdriveCalculator struct {
+	client StarshipClient
+}
+
+// NewHyperdriveCalculator creates a new instance of HyperdriveCalculator with the provided client
+func NewHyperdriveCalculator(client StarshipClient) *HyperdriveCalculator {
+	return &HyperdriveCalculator{
+		client: client,
+	}
+}
+
+// CalculateStops determines how many stops each starship needs to make for a given
+// distance travelled through hyperspace
+func (c *HyperdriveCalculator) CalculateStops(ctx context.Context, distance int64, opts Options) (Calculation, error) {
+	starships, err := c.client.GetStarships(ctx)
+	if err != nil {
+		return Calculation{}, fmt.Errorf("fetch starships: %w", err)
+	}
+
+	starships, overridden, err := applyOverrides(starships, opts.Overrides)
+	if err != nil {
+		return Calculation{}, err
+	}
+	fleet, skipped := reserveFleet(starships, opts.Reserve)
+	fleet, unrated := hyperspaceFleet(fleet)
+	skipped = append(skipped, unrated...)
+	result := stopsForFleet(fleet, distance, opts)
+	result.Skipped = append(skipped, result.Skipped...)
+	result.Overridden = overridden
+	return result, nil
+}
+
+// CalculateStopsBatch determines the hyperspace stops of each starship for several
+// distances, fetching the fleet only once. Results are returned in the order of distances.
+func (c *HyperdriveCalculator) CalculateStopsBatch(ctx context.Context, distances []int64, opts Options) ([]Calculation, error) {
+	starships, err := c.client.GetStarships(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("fetch starships: %w", err)
+	}
+
+	starships, overridden, err := applyOverrides(starships, opts.Overrides)
+	if err != nil {
+		return nil, err
+	}
+	fleet, skipped := reserveFleet(starships, opts.Reserve)
+	fleet, unrated := hyperspaceFleet(fleet)
+	skipped = append(skipped, unrated...)
+	results := make([]Calculation, len(distances))
+	for i, distance := range distances {
+		results[i] = stopsForFleet(fleet, distance, opts)
+		results[i].Skipped = append(skipped[:len(skipped):len(skipped)], results[i].Skipped...)
+		results[i].Overridden = overridden
+	}
+	return results, nil
+}
+
+// MaxRanges determines the longest distance each starship covers through hyperspace
+// making at most the given number of stops, with the overrides and reserve of the options
+func (c *HyperdriveCalculator) MaxRanges(ctx context.Context, stops int64, opts Options) (RangeCalculation, error) {
+	starships, err := c.client.GetStarships(ctx)
+	if err != nil {
+		return RangeCalculation{}, fmt.Errorf("fetch starships: %w", err)
+	}
+
+	starships, _, err = applyOverrides(starships, opts.Overrides)
+	if err != nil {
+		return RangeCalculation{}, err
+	}
+	fleet, skipped := reserveFleet(starships, opts.Reserve)
+	fleet, unrated := hyperspaceFleet(fleet)
+	skipped = append(skipped, unrated...)
+	result := rangesForFleet(fleet, stops)
+	result.Skipped = append(skipped, result.Skipped...)
+	return result, nil
+}
+
+// hyperspaceFleet converts every starship to its hyperspace speed, leaving out the
+// starships whose data can't be used. Skipped starships keep their sublight data.
+func hyperspaceFleet(starships []domain.Starship) ([]domain.Starship, []SkippedStarship) {
+	fleet := make([]domain.Starship, 0, len(starships))
+	var skipped []SkippedStarship
+	for _, ship := range starships {
+		hyperspace, err := HyperspaceStarship(ship)
+		if err != nil {
+			skipped = append(skipped, skip(ship, err))
+			continue
+		}
+		fleet = append(fleet, hyperspace)
+	}
+	return fleet, skipped
+}
+
+// HyperspaceStarship returns a copy of the starship whose MGLT is its hyperspace speed,
+// MGLT divided by the hyperdrive rating, rounded down.
+// Returns the StopsForShip errors for unusable sublight data, ErrUnknownHyperdrive when
+// the starship has no positive hyperdrive rating, and ErrZeroSpeed when its hyperspace
+// speed rounds down to 0.
+func HyperspaceStarship(ship domain.Starship) (domain.Starship, error) {
+	if _, _, err := shipRange(ship); err != nil && !errors.Is(err, ErrOverflow) {
+		return domain.Starship{}, err
+	}
+	if !ship.HyperdriveRating.Known || ship.HyperdriveRating.Value <= 0 {
+		return domain.Starship{}, fmt.Errorf("hyperdrive of %s: %w", ship.Name, ErrUnknownHyperdrive)
+	}
+
+	speed := math.Floor(float64(ship.MGLT) / ship.HyperdriveRating.Value)
+	switch {
+	case speed >= math.MaxInt:
+		ship.MGLT = math.MaxInt
+	case speed < 1:
+		return domain.Starship{}, fmt.Errorf("hyperspace speed of %s: %w", ship.Name, ErrZeroSpeed)
+	default:
+		ship.MGLT = int(speed)
+	}
+	return ship, nil
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/pvdevs/get-starships-stops/internal/domain"
+)
+
+// TestHyperspaceStarship verifies the hyperspace speed of a starship.
+// It tests scenarios including:
+// - Ratings below and above class 1 speeding up and slowing down the starship
+// - Speeds rounded down, and rounding down to 0 reported as a zero speed
+// - Starships without a usable hyperdrive rating or sublight data
+func TestHyperspaceStarship(t *testing.T) {
+	rated := func(mglt int, rating float64) domain.Starship {
+		return domain.Starship{Name: "Ship", MGLT: mglt, Consumables: "1 week", HyperdriveRating: domain.Measure{Value: rating, Known: true}}
+	}
+
+	tests := []struct {
+		name     string          // Test case description
+		ship     domain.Starship // Starship to convert
+		wantMGLT int             // Expected hyperspace MGLT
+		wantErr  error           // Expected error
+	}{
+		{name: "class 0.5", ship: rated(75, 0.5), wantMGLT: 150},
+		{name: "class 2", ship: rated(60, 2), wantMGLT: 30},
+		{name: "rounded down", ship: rated(70, 3), wantMGLT: 23},
+		{name: "rounded down to zero", ship: rated(1, 6), wantErr: ErrZeroSpeed},
+		{name: "unknown rating", ship: domain.Starship{Name: "Ship", MGLT: 75, Consumables: "1 week"}, wantErr: ErrUnknownHyperdrive},
+		{name: "zero rating", ship: rated(75, 0), wantErr: ErrUnknownHyperdrive},
+		{name: "unknown MGLT", ship: domain.Starship{Name: "Ship", UnknownMGLT: true, Consumables: "1 week"}, wantErr: ErrUnknownMGLT},
+		{name: "zero speed", ship: rated(0, 1), wantErr: ErrZeroSpeed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := HyperspaceStarship(tt.ship)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("HyperspaceStarship() error = %v, want %v", err, tt.wantErr)
+			}
+			if got.MGLT != tt.wantMGLT {
+				t.Errorf("HyperspaceStarship() MGLT = %d, want %d", got.MGLT, tt.wantMGLT)
+			}
+		})
+	}
+}
+
+// TestHyperdriveCalculator verifies that the hyperdrive model computes stops, travel
+// time and ranges from the hyperspace speed, skipping starships without a rating.
+func TestHyperdriveCalculator(t *testing.T) {
+	calculator := NewHyperdriveCalculator(&mockStarshipClient{starships: []domain.Starship{
+		{Name: "Millennium Falcon", MGLT: 75, Consumables: "2 months", HyperdriveRating: domain.Measure{Value: 0.5, Known: true}}, // 216000 MGLT per leg
+		{Name: "X-wing", MGLT: 100, Consumables: "1 week", HyperdriveRating: domain.Measure{Value: 1, Known: true}},               // 16800 MGLT per leg
+		{Name: "Rebel transport", MGLT: 20, Consumables: "6 months"},
+	}})
+
+	calculation, err := calculator.CalculateStops(context.Background(), 1000000, Options{})
+	if err != nil {
+		t.Fatalf("CalculateStops() error = %v", err)
+	}
+	if calculation.Stops["Millennium Falcon"] != 4 || calculation.Stops["X-wing"] != 59 {
+		t.Errorf("unexpected stops %v", calculation.Stops)
+	}
+	if travel := calculation.Travel["Millennium Falcon"]; travel.Hours != 6667 {
+		t.Errorf("expected 6667 hyperspace hours, got %+v", travel)
+	}
+	if len(calculation.Skipped) != 1 || calculation.Skipped[0].Reason != SkipUnknownHyperdrive || calculation.Skipped[0].Starship.MGLT != 20 {
+		t.Errorf("expected the transport skipped with its sublight data, got %+v", calculation.Skipped)
+	}
+
+	batch, err := calculator.CalculateStopsBatch(context.Background(), []int64{1000000, 216000}, Options{})
+	if err != nil {
+		t.Fatalf("CalculateStopsBatch() error = %v", err)
+	}
+	if len(batch) != 2 || batch[1].Stops["Millennium Falcon"] != 0 || len(batch[1].Skipped) != 1 {
+		t.Errorf("unexpected batch %+v", batch)
+	}
+
+	ranges, err := calculator.MaxRanges(context.Background(), 1, Options{})
+	if err != nil {
+		t.Fatalf("MaxRanges() error = %v", err)
+	}
+	if ranges.Ranges["Millennium Falcon"] != 432000 || ranges.Ranges["X-wing"] != 33600 || len(ranges.Skipped) != 1 {
+		t.Errorf("unexpected ranges %+v", ranges)
+	}
+}
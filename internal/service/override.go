@@ -0,0 +1,92 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/pvdevs/get-starships-stops/internal/domain"
+)
+
+var (
+	ErrInvalidOverride = errors.New("invalid starship override")
+)
+
+// Values a starship override can replace, as reported in Calculation.Overridden
+const (
+	OverrideMGLT        = "mglt"
+	OverrideConsumables = "consumables"
+)
+
+// Override replaces values of a starship for a single calculation, to answer questions
+// such as "what if the Falcon had 3 months of consumables?"
+type Override struct {
+	MGLT        *int    // Replacement MGLT, nil keeps the fetched one
+	Consumables *string // Replacement consumables, nil keeps the fetched one
+}
+
+// OverrideError is returned when override keys match no starship.
+// It matches ErrInvalidOverride and carries the unmatched keys with their suggestions.
+type OverrideError struct {
+	Unknown []*NotFoundError // One entry per override key matching no starship
+}
+
+func (e *OverrideError) Error() string {
+	keys := make([]string, 0, len(e.Unknown))
+	for _, notFound := range e.Unknown {
+		key := fmt.Sprintf("%q", notFound.Key)
+		if len(notFound.Suggestions) > 0 {
+			key += fmt.Sprintf(" (did you mean %s?)", strings.Join(notFound.Suggestions, ", "))
+		}
+		keys = append(keys, key)
+	}
+	return fmt.Sprintf("no starship matches the overrides %s", strings.Join(keys, ", "))
+}
+
+// Is makes errors.Is(err, ErrInvalidOverride) match an OverrideError
+func (e *OverrideError) Is(target error) bool {
+	return target == ErrInvalidOverride
+}
+
+// applyOverrides returns a copy of the fleet patched with overrides keyed by SWAPI id or
+// name, and the values replaced per starship name. The fetched fleet is left untouched.
+// It returns an OverrideError for keys matching no starship, and ErrInvalidOverride when
+// two keys match the same starship.
+func applyOverrides(starships []domain.Starship, overrides map[string]Override) ([]domain.Starship, map[string][]string, error) {
+	if len(overrides) == 0 {
+		return starships, nil, nil
+	}
+
+	fleet := slices.Clone(starships)
+	overridden := make(map[string][]string, len(overrides))
+	matched := make(map[int]string, len(overrides))
+	var unknown []*NotFoundError
+	for _, key := range slices.Sorted(maps.Keys(overrides)) {
+		i := starshipIndex(fleet, key)
+		if i < 0 {
+			unknown = append(unknown, &NotFoundError{Key: key, Suggestions: suggestNames(fleet, key)})
+			continue
+		}
+		if other, ok := matched[i]; ok {
+			return nil, nil, fmt.Errorf("%w: %q and %q both override %s", ErrInvalidOverride, other, key, fleet[i].Name)
+		}
+		matched[i] = key
+
+		ship, override := &fleet[i], overrides[key]
+		if override.MGLT != nil {
+			ship.MGLT, ship.UnknownMGLT = *override.MGLT, false
+			overridden[ship.Name] = append(overridden[ship.Name], OverrideMGLT)
+		}
+		if override.Consumables != nil {
+			ship.Consumables = *override.Consumables
+			overridden[ship.Name] = append(overridden[ship.Name], OverrideConsumables)
+		}
+	}
+
+	if len(unknown) > 0 {
+		return nil, nil, &OverrideError{Unknown: unknown}
+	}
+	return fleet, overridden, nil
+}
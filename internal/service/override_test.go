@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/pvdevs/get-starships-stops/internal/domain"
+)
+
+// TestCalculator_overrides verifies what-if calculations with overridden starship values.
+// It tests scenarios including:
+// - Overrides keyed by id or case-insensitive name, reported per starship
+// - Overrides making a skipped starship usable
+// - Unknown keys and keys overriding the same starship twice
+func TestCalculator_overrides(t *testing.T) {
+	mglt := func(n int) *int { return &n }
+	consumables := func(s string) *string { return &s }
+
+	tests := []struct {
+		name           string              // Test case description
+		overrides      map[string]Override // Overrides of the calculation
+		wantStops      map[string]int64    // Expected stops per starship
+		wantOverridden map[string][]string // Expected overridden values per starship
+		wantErr        error               // Expected error
+	}{
+		{
+			name:      "by name",
+			overrides: map[string]Override{"millennium falcon": {Consumables: consumables("3 months")}},
+			// 2160 hours × 75 MGLT = 162000 per leg
+			wantStops:      map[string]int64{"Millennium Falcon": 6, "X-wing": 59},
+			wantOverridden: map[string][]string{"Millennium Falcon": {OverrideConsumables}},
+		},
+		{
+			name:           "by id, both values",
+			overrides:      map[string]Override{"12": {MGLT: mglt(200), Consumables: consumables("2 weeks")}},
+			wantStops:      map[string]int64{"Millennium Falcon": 9, "X-wing": 14},
+			wantOverridden: map[string][]string{"X-wing": {OverrideMGLT, OverrideConsumables}},
+		},
+		{
+			name:           "unknown MGLT made usable",
+			overrides:      map[string]Override{"Executor": {MGLT: mglt(40)}},
+			wantStops:      map[string]int64{"Millennium Falcon": 9, "X-wing": 59, "Executor": 0},
+			wantOverridden: map[string][]string{"Executor": {OverrideMGLT}},
+		},
+		{
+			name:      "unknown key",
+			overrides: map[string]Override{"Falcon": {MGLT: mglt(80)}},
+			wantErr:   ErrInvalidOverride,
+		},
+		{
+			name: "same starship twice",
+			overrides: map[string]Override{
+				"10":                {MGLT: mglt(80)},
+				"Millennium Falcon": {MGLT: mglt(90)},
+			},
+			wantErr: ErrInvalidOverride,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			starships := []domain.Starship{
+				{ID: "10", Name: "Millennium Falcon", MGLT: 75, Consumables: "2 months"},
+				{ID: "12", Name: "X-wing", MGLT: 100, Consumables: "1 week"},
+				{ID: "15", Name: "Executor", UnknownMGLT: true, Consumables: "6 years"},
+			}
+			fetched := append([]domain.Starship(nil), starships...)
+			calculator := NewCalculator(&mockStarshipClient{starships: starships})
+
+			calculation, err := calculator.CalculateStops(context.Background(), 1000000, Options{Overrides: tt.overrides})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CalculateStops() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(starships, fetched) {
+				t.Errorf("expected the fetched fleet to be left untouched, got %+v", starships)
+			}
+			if err != nil {
+				return
+			}
+			if !reflect.DeepEqual(calculation.Stops, tt.wantStops) {
+				t.Errorf("expected stops %v, got %v", tt.wantStops, calculation.Stops)
+			}
+			if !reflect.DeepEqual(calculation.Overridden, tt.wantOverridden) {
+				t.Errorf("expected overridden %v, got %v", tt.wantOverridden, calculation.Overridden)
+			}
+		})
+	}
+}
+
+// TestOverrideError verifies that unknown override keys are reported with suggestions.
+func TestOverrideError(t *testing.T) {
+	starships := []domain.Starship{{ID: "10", Name: "Millennium Falcon", MGLT: 75, Consumables: "2 months"}}
+	_, _, err := applyOverrides(starships, map[string]Override{"Millenium Falcon": {}})
+
+	var overrideErr *OverrideError
+	if !errors.As(err, &overrideErr) || len(overrideErr.Unknown) != 1 {
+		t.Fatalf("expected an OverrideError, got %v", err)
+	}
+	if want := `no starship matches the overrides "Millenium Falcon" (did you mean Millennium Falcon?)`; err.Error() != want {
+		t.Errorf("expected error %q, got %q", want, err.Error())
+	}
+}
+
+// TestOverrides_rangesAndRecommendations verifies that maximum ranges and recommendations
+// use overridden starship values, and reject overrides matching no starship.
+func TestOverrides_rangesAndRecommendations(t *testing.T) {
+	consumables := "2 weeks"
+	client := &mockStarshipClient{starships: []domain.Starship{
+		{ID: "10", Name: "Millennium Falcon", MGLT: 75, Consumables: "2 months"},
+		{ID: "12", Name: "X-wing", MGLT: 100, Consumables: "1 week"},
+	}}
+	opts := Options{Overrides: map[string]Override{"12": {Consumables: &consumables}}}
+
+	ranges, err := NewCalculator(client).MaxRanges(context.Background(), 0, opts)
+	if err != nil {
+		t.Fatalf("MaxRanges() error = %v", err)
+	}
+	// 336 hours × 100 MGLT
+	if ranges.Ranges["X-wing"] != 33600 {
+		t.Errorf("expected the overridden X-wing to cover 33600 MGLT, got %v", ranges.Ranges)
+	}
+
+	recommendations, err := NewRecommender(client).Recommend(context.Background(), 1000000, Constraints{}, opts)
+	if err != nil {
+		t.Fatalf("Recommend() error = %v", err)
+	}
+	if len(recommendations) != 2 || recommendations[1].Starship.Name != "X-wing" || recommendations[1].Stops != 29 {
+		t.Errorf("expected the overridden X-wing second with 29 stops, got %+v", recommendations)
+	}
+
+	unknown := Options{Overrides: map[string]Override{"Falcon": {Consumables: &consumables}}}
+	if _, err := NewHyperdriveCalculator(client).MaxRanges(context.Background(), 0, unknown); !errors.Is(err, ErrInvalidOverride) {
+		t.Errorf("MaxRanges() error = %v, want %v", err, ErrInvalidOverride)
+	}
+	if _, err := NewRecommender(client).Recommend(context.Background(), 1000000, Constraints{}, unknown); !errors.Is(err, ErrInvalidOverride) {
+		t.Errorf("Recommend() error = %v, want %v", err, ErrInvalidOverride)
+	}
+}
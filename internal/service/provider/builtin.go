@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/pvdevs/get-starships-stops/internal/config"
+	"github.com/pvdevs/get-starships-stops/internal/domain"
+	"github.com/pvdevs/get-starships-stops/internal/service"
+	"github.com/pvdevs/get-starships-stops/internal/service/swapi"
+	"github.com/pvdevs/get-starships-stops/internal/service/swapitech"
+)
+
+func init() {
+	Register("swapi", newSWAPIDev)
+	Register("swapi.dev", newSWAPIDev)
+	Register("http", newSWAPIDev)
+	Register("https", newSWAPIDev)
+	Register("swapi.tech", newSWAPITech)
+	Register("file", newSnapshot)
+	Register("mock", newMock)
+}
+
+// newSWAPIDev creates a client for swapi.dev, or for a mirror with the same
+// schema when the source is an http(s) URL
+func newSWAPIDev(cfg *config.Config, target string) (service.StarshipClient, error) {
+	baseURL := cfg.SWAPIURL
+	if strings.Contains(target, "://") {
+		baseURL = strings.TrimSuffix(target, "/")
+	}
+	return swapi.NewClient(swapi.ClientConfig{
+		BaseURL:        baseURL,
+		MaxRetries:     cfg.SWAPIMaxRetries,
+		RetryBaseDelay: cfg.SWAPIRetryBaseDelay,
+		RetryMaxDelay:  cfg.SWAPIRetryMaxDelay,
+		Concurrency:    cfg.SWAPIConcurrency,
+		Breaker: swapi.BreakerConfig{
+			FailureRate: cfg.BreakerFailureRate,
+			Window:      cfg.BreakerWindow,
+			MinRequests: cfg.BreakerMinRequests,
+			OpenTimeout: cfg.BreakerOpenTimeout,
+		},
+	}), nil
+}
+
+// newSWAPITech creates a client for swapi.tech
+func newSWAPITech(cfg *config.Config, target string) (service.StarshipClient, error) {
+	return swapitech.NewClient(swapitech.ClientConfig{
+		BaseURL: cfg.SWAPITechURL,
+	}), nil
+}
+
+// newSnapshot creates a client serving a snapshot file, given as file:///path
+func newSnapshot(cfg *config.Config, target string) (service.StarshipClient, error) {
+	source, err := url.Parse(target)
+	if err != nil {
+		return nil, fmt.Errorf("parse snapshot URL: %w", err)
+	}
+	return swapi.NewSnapshotClient(source.Host + source.Path)
+}
+
+// newMock creates a client serving a small fixed fleet, for demos and local development
+func newMock(cfg *config.Config, target string) (service.StarshipClient, error) {
+	return mockClient{}, nil
+}
+
+// mockFleet is the fleet served by the mock source
+var mockFleet = []domain.Starship{
+	{
+		ID: "10", Name: "Millennium Falcon", MGLT: 75, Consumables: "2 months",
+		Model: "YT-1300 light freighter", Manufacturers: []string{"Corellian Engineering Corporation"}, Class: "Light freighter",
+		Cost: count(100000), Length: measure(34.37), MaxAtmospheringSpeed: measure(1050), HyperdriveRating: measure(0.5),
+		Crew: count(4), Passengers: count(6), CargoCapacity: count(100000),
+	},
+	{
+		ID: "12", Name: "X-wing", MGLT: 100, Consumables: "1 week",
+		Model: "T-65 X-wing", Manufacturers: []string{"Incom Corporation"}, Class: "Starfighter",
+		Cost: count(149999), Length: measure(12.5), MaxAtmospheringSpeed: measure(1050), HyperdriveRating: measure(1),
+		Crew: count(1), Passengers: count(0), CargoCapacity: count(110),
+	},
+	{
+		ID: "11", Name: "Y-wing", MGLT: 80, Consumables: "1 week",
+		Model: "BTL Y-wing", Manufacturers: []string{"Koensayr Manufacturing"}, Class: "assault starfighter",
+		Cost: count(134999), Length: measure(14), MaxAtmospheringSpeed: measure(1000), HyperdriveRating: measure(1),
+		Crew: count(2), Passengers: count(0), CargoCapacity: count(110),
+	},
+	{
+		ID: "3", Name: "Star Destroyer", MGLT: 60, Consumables: "2 years",
+		Model: "Imperial I-class Star Destroyer", Manufacturers: []string{"Kuat Drive Yards"}, Class: "Star Destroyer",
+		Cost: count(150000000), Length: measure(1600), MaxAtmospheringSpeed: measure(975), HyperdriveRating: measure(2),
+		Crew: count(47060), CargoCapacity: count(36000000),
+	},
+}
+
+// count returns a known quantity of exactly n
+func count(n int64) domain.Quantity {
+	return domain.Quantity{Min: n, Max: n, Known: true}
+}
+
+// measure returns a known measure of v
+func measure(v float64) domain.Measure {
+	return domain.Measure{Value: v, Known: true}
+}
+
+// mockClient serves a copy of mockFleet
+type mockClient struct{}
+
+func (mockClient) GetStarships(ctx context.Context) ([]domain.Starship, error) {
+	return append([]domain.Starship(nil), mockFleet...), nil
+}
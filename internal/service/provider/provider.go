@@ -0,0 +1,101 @@
+package provider
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/pvdevs/get-starships-stops/internal/config"
+	"github.com/pvdevs/get-starships-stops/internal/service"
+)
+
+// Factory creates a StarshipClient for a source. The target is the full source
+// string, for example "swapi.tech" or "file:///data/starships.json".
+type Factory func(cfg *config.Config, target string) (service.StarshipClient, error)
+
+var (
+	mu        sync.RWMutex
+	factories = make(map[string]Factory)
+)
+
+// Register makes a factory available under a name or URL scheme.
+// It panics if the key is already taken, as that is always a programming error.
+func Register(key string, factory Factory) {
+	mu.Lock()
+	defer mu.Unlock()
+	if _, exists := factories[key]; exists {
+		panic(fmt.Sprintf("provider: %q registered twice", key))
+	}
+	factories[key] = factory
+}
+
+// New creates the StarshipClient for a source. Sources are either a registered
+// name ("swapi.dev", "swapi.tech", "mock") or a URL whose scheme is registered
+// ("file:///path", "https://mirror.example").
+func New(cfg *config.Config, source string) (service.StarshipClient, error) {
+	key := source
+	if scheme, _, found := strings.Cut(source, "://"); found {
+		key = scheme
+	}
+
+	mu.RLock()
+	factory, ok := factories[key]
+	mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown starship source %q, expected one of %s", source, strings.Join(Names(), ", "))
+	}
+
+	client, err := factory(cfg, source)
+	if err != nil {
+		return nil, fmt.Errorf("create %s source: %w", key, err)
+	}
+	return client, nil
+}
+
+// NewFromConfig creates the client for cfg.StarshipSource. When fallback sources are
+// configured, it returns a FallbackClient trying the main source first and then
+// every fallback in order.
+func NewFromConfig(cfg *config.Config) (service.StarshipClient, error) {
+	primary, err := New(cfg, cfg.StarshipSource)
+	if err != nil {
+		return nil, err
+	}
+	if len(cfg.StarshipFallbacks) == 0 {
+		return primary, nil
+	}
+
+	sources := []service.FallbackSource{{
+		Name:    cfg.StarshipSource,
+		Client:  primary,
+		Timeout: cfg.SourceTimeout,
+	}}
+	for _, source := range cfg.StarshipFallbacks {
+		client, err := New(cfg, source)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, service.FallbackSource{
+			Name:    source,
+			Client:  client,
+			Timeout: cfg.SourceTimeout,
+		})
+	}
+
+	return service.NewFallbackClient(sources, service.FallbackConfig{
+		FailureThreshold: cfg.SourceFailureThreshold,
+		Cooldown:         cfg.SourceCooldown,
+	}), nil
+}
+
+// Names returns the registered names and schemes, sorted
+func Names() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	names := make([]string, 0, len(factories))
+	for name := range factories {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
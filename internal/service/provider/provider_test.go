@@ -0,0 +1,127 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pvdevs/get-starships-stops/internal/config"
+	"github.com/pvdevs/get-starships-stops/internal/service"
+	"github.com/pvdevs/get-starships-stops/internal/service/swapi"
+	"github.com/pvdevs/get-starships-stops/internal/service/swapitech"
+)
+
+// TestNew verifies that sources are resolved to the right provider.
+// It tests cases including:
+// - Registered names
+// - Registered URL schemes
+// - Unknown sources and provider creation errors
+func TestNew(t *testing.T) {
+	snapshot := filepath.Join(t.TempDir(), "starships.json")
+	if err := os.WriteFile(snapshot, []byte(`{"count":1,"results":[{"name":"X-wing","MGLT":"100","consumables":"1 week"}]}`), 0o644); err != nil {
+		t.Fatalf("write snapshot: %v", err)
+	}
+
+	tests := []struct {
+		name    string // Test case description
+		source  string // Configured source
+		wantErr bool   // Whether an error is expected
+		check   func(t *testing.T, client any)
+	}{
+		{
+			name:   "swapi.dev by name",
+			source: "swapi.dev",
+			check:  wantType[*swapi.Client],
+		},
+		{
+			name:   "swapi.dev mirror by URL",
+			source: "https://swapi.py4e.com",
+			check:  wantType[*swapi.Client],
+		},
+		{
+			name:   "swapi.tech by name",
+			source: "swapi.tech",
+			check:  wantType[*swapitech.Client],
+		},
+		{
+			name:   "snapshot file",
+			source: "file://" + snapshot,
+			check:  wantType[*swapi.SnapshotClient],
+		},
+		{
+			name:   "mock",
+			source: "mock",
+			check:  wantType[mockClient],
+		},
+		{
+			name:    "missing snapshot file",
+			source:  "file:///does/not/exist.json",
+			wantErr: true,
+		},
+		{
+			name:    "unknown source",
+			source:  "swapi.example",
+			wantErr: true,
+		},
+	}
+
+	cfg := &config.Config{SWAPIURL: "https://swapi.dev", SWAPITechURL: "https://www.swapi.tech"}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := New(cfg, tt.source)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("New(%q) error = %v, wantErr %v", tt.source, err, tt.wantErr)
+			}
+			if err == nil {
+				tt.check(t, client)
+			}
+		})
+	}
+}
+
+// TestMockClient verifies that the mock source serves a usable fleet that callers can't alter.
+func TestMockClient(t *testing.T) {
+	client := mockClient{}
+	first, _ := client.GetStarships(context.Background())
+	if len(first) == 0 {
+		t.Fatal("expected mock fleet to contain starships")
+	}
+
+	first[0].Name = "changed"
+	second, _ := client.GetStarships(context.Background())
+	if second[0].Name == "changed" {
+		t.Error("expected mock fleet to be copied for every call")
+	}
+}
+
+// TestNewFromConfig_breakerHealth verifies that a fallback chain reports the circuit
+// breaker state of its SWAPI sources, and none for the sources without a breaker.
+func TestNewFromConfig_breakerHealth(t *testing.T) {
+	cfg := &config.Config{
+		SWAPIURL:           "https://swapi.dev",
+		StarshipSource:     "swapi.dev",
+		StarshipFallbacks:  []string{"mock"},
+		BreakerFailureRate: 0.5,
+	}
+	client, err := NewFromConfig(cfg)
+	if err != nil {
+		t.Fatalf("NewFromConfig() error = %v", err)
+	}
+
+	fallback, ok := client.(*service.FallbackClient)
+	if !ok {
+		t.Fatalf("expected a fallback chain, got %T", client)
+	}
+	health := fallback.Health()
+	if len(health) != 2 || health[0].Breaker != swapi.BreakerClosed.String() || health[1].Breaker != "" {
+		t.Errorf("expected the breaker state of the SWAPI source only, got %+v", health)
+	}
+}
+
+func wantType[T any](t *testing.T, client any) {
+	t.Helper()
+	if _, ok := client.(T); !ok {
+		t.Errorf("expected client of type %T, got %T", *new(T), client)
+	}
+}
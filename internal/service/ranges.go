@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/pvdevs/get-starships-stops/internal/domain"
+)
+
+// RangeCalculation holds the longest distance every starship covers with a stop budget,
+// and the starships left out because their data can't be used
+type RangeCalculation struct {
+	Ranges    map[string]int64  // Starship names to the longest distance they cover
+	Unbounded []string          // Starships whose range exceeds an int64, they cover any distance
+	Skipped   []SkippedStarship // Starships left out of Ranges, with the reason why
+}
+
+// MaxRanges determines the longest distance each starship covers making at most the given
+// number of stops. It is the inverse of CalculateStops: a starship covering exactly its
+// maximum range needs exactly stops stops. The overrides and reserve of the options apply,
+// ranges always use the exact rule whatever the strategy.
+func (c *Calculator) MaxRanges(ctx context.Context, stops int64, opts Options) (RangeCalculation, error) {
+	starships, err := c.client.GetStarships(ctx)
+	if err != nil {
+		return RangeCalculation{}, fmt.Errorf("fetch starships: %w", err)
+	}
+
+	starships, _, err = applyOverrides(starships, opts.Overrides)
+	if err != nil {
+		return RangeCalculation{}, err
+	}
+	fleet, skipped := reserveFleet(starships, opts.Reserve)
+	result := rangesForFleet(fleet, stops)
+	result.Skipped = append(skipped, result.Skipped...)
+	return result, nil
+}
+
+// rangesForFleet computes the longest distance every starship in the fleet covers with a stop budget
+func rangesForFleet(starships []domain.Starship, stops int64) RangeCalculation {
+	result := RangeCalculation{Ranges: make(map[string]int64)}
+	for _, ship := range starships {
+		maxRange, err := MaxRangeForShip(ship, stops)
+		switch {
+		case errors.Is(err, ErrOverflow):
+			result.Unbounded = append(result.Unbounded, ship.Name)
+		case err != nil:
+			result.Skipped = append(result.Skipped, skip(ship, err))
+		default:
+			result.Ranges[ship.Name] = maxRange
+		}
+	}
+	return result
+}
+
+// MaxRangeForShip determines the longest distance a single starship covers making at most
+// the given number of stops, (stops + 1) × MGLT × autonomy hours.
+// Returns ErrOverflow when the distance doesn't fit in an int64.
+func MaxRangeForShip(ship domain.Starship, stops int64) (int64, error) {
+	_, maxDistance, err := shipRange(ship)
+	if err != nil {
+		return 0, err
+	}
+	if stops == math.MaxInt64 {
+		return 0, fmt.Errorf("range of %s: %w", ship.Name, ErrOverflow)
+	}
+
+	maxRange, err := mulInt64(stops+1, maxDistance)
+	if err != nil {
+		return 0, fmt.Errorf("range of %s: %w", ship.Name, err)
+	}
+	return maxRange, nil
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/pvdevs/get-starships-stops/internal/domain"
+)
+
+// TestMaxRangeForShip verifies the longest distance a starship covers with a stop budget.
+// It tests scenarios including:
+// - Ranges that are the exact inverse of StopsForShip
+// - Ranges that overflow an int64
+// - Starships with unusable data
+func TestMaxRangeForShip(t *testing.T) {
+	xwing := domain.Starship{Name: "X-wing", MGLT: 100, Consumables: "1 week"} // 16800 MGLT per leg
+
+	tests := []struct {
+		name    string          // Test case description
+		ship    domain.Starship // Starship travelling
+		stops   int64           // Stop budget
+		want    int64           // Expected maximum range
+		wantErr error           // Expected error
+	}{
+		{name: "no stops", ship: xwing, stops: 0, want: 16800},
+		{name: "several stops", ship: xwing, stops: 59, want: 1008000},
+		{name: "overflowing range", ship: xwing, stops: math.MaxInt64 / 1000, wantErr: ErrOverflow},
+		{name: "largest budget", ship: xwing, stops: math.MaxInt64, wantErr: ErrOverflow},
+		{name: "zero speed", ship: domain.Starship{Name: "Death Star", Consumables: "3 years"}, stops: 1, wantErr: ErrZeroSpeed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MaxRangeForShip(tt.ship, tt.stops)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("MaxRangeForShip() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("MaxRangeForShip() = %d, want %d", got, tt.want)
+			}
+			if err != nil {
+				return
+			}
+
+			// The maximum range needs exactly the budget, one MGLT more needs another stop
+			if stops, _ := StopsForShip(tt.ship, got); stops != tt.stops {
+				t.Errorf("StopsForShip(%d) = %d, want %d", got, stops, tt.stops)
+			}
+			if stops, _ := StopsForShip(tt.ship, got+1); stops != tt.stops+1 {
+				t.Errorf("StopsForShip(%d) = %d, want %d", got+1, stops, tt.stops+1)
+			}
+		})
+	}
+}
+
+// TestMaxRanges verifies that the ranges of a fleet separate bounded, unbounded and skipped starships.
+func TestMaxRanges(t *testing.T) {
+	calculator := NewCalculator(&mockStarshipClient{starships: []domain.Starship{
+		{Name: "X-wing", MGLT: 100, Consumables: "1 week"},
+		{Name: "Eternal", MGLT: 10000, Consumables: "1000000000000 years"},
+		{Name: "Executor", UnknownMGLT: true, Consumables: "6 years"},
+	}})
+
+	result, err := calculator.MaxRanges(context.Background(), 1, Options{})
+	if err != nil {
+		t.Fatalf("MaxRanges() error = %v", err)
+	}
+	if len(result.Ranges) != 1 || result.Ranges["X-wing"] != 33600 {
+		t.Errorf("expected X-wing to cover 33600 MGLT, got %v", result.Ranges)
+	}
+	if len(result.Unbounded) != 1 || result.Unbounded[0] != "Eternal" {
+		t.Errorf("expected Eternal to be unbounded, got %v", result.Unbounded)
+	}
+	if len(result.Skipped) != 1 || result.Skipped[0].Reason != SkipUnknownMGLT {
+		t.Errorf("expected Executor to be skipped, got %+v", result.Skipped)
+	}
+}
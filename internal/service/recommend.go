@@ -0,0 +1,146 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/pvdevs/get-starships-stops/internal/domain"
+)
+
+const (
+	defaultRecommendations = 5
+)
+
+// Constraints restrict the starships a recommendation may contain.
+// Zero values don't restrict anything.
+type Constraints struct {
+	MinPassengers int64  // Passengers the starship must be able to carry
+	MinCargo      int64  // Cargo capacity the starship must have, in metric tons
+	MaxStops      *int64 // Most stops the starship may make, nil for no limit
+	Class         string // Starship class, matched case-insensitively
+	Limit         int    // Most recommendations returned, 5 when not positive
+}
+
+// Recommendation is a starship able to cover a distance, with its stops and travel time
+type Recommendation struct {
+	Starship    domain.Starship
+	Stops       int64
+	Travel      *Travel // Nil when the travel time can't be computed
+	TravelError error   // Why Travel is nil, such as ErrOverflow
+}
+
+// RecommenderService defines the interface for recommending starships for a distance
+type RecommenderService interface {
+	Recommend(ctx context.Context, distance int64, constraints Constraints, opts Options) ([]Recommendation, error)
+}
+
+// Recommender ranks the starships of the fleet able to cover a distance
+type Recommender struct {
+	client     StarshipClient
+	hyperspace bool // Whether starships travel at their hyperspace speed, as with HyperdriveCalculator
+}
+
+// NewRecommender creates a new instance of Recommender with the provided client
+func NewRecommender(client StarshipClient) *Recommender {
+	return &Recommender{
+		client: client,
+	}
+}
+
+// NewHyperdriveRecommender creates a Recommender ranking starships by their hyperspace
+// travel, leaving out the starships without a hyperdrive rating
+func NewHyperdriveRecommender(client StarshipClient) *Recommender {
+	return &Recommender{
+		client:     client,
+		hyperspace: true,
+	}
+}
+
+// Recommend returns the best starships for a distance among those meeting the constraints,
+// ranked by stops, then total travel time, then name. Starships whose stops can't be
+// calculated, whose autonomy doesn't cover the reserve, or whose counts are unknown when
+// a minimum is required, are left out. Recommended starships carry the values replaced by
+// the overrides of the options.
+func (r *Recommender) Recommend(ctx context.Context, distance int64, constraints Constraints, opts Options) ([]Recommendation, error) {
+	starships, err := r.client.GetStarships(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("fetch starships: %w", err)
+	}
+
+	starships, _, err = applyOverrides(starships, opts.Overrides)
+	if err != nil {
+		return nil, err
+	}
+	fleet, _ := reserveFleet(starships, opts.Reserve)
+	var recommendations []Recommendation
+	for _, ship := range fleet {
+		if !constraints.allow(ship) {
+			continue
+		}
+
+		// Recommendations show the fetched starship, whatever speed it travels at
+		travelling := ship
+		if r.hyperspace {
+			if travelling, err = HyperspaceStarship(ship); err != nil {
+				continue
+			}
+		}
+
+		stops, err := opts.stops(travelling, distance)
+		if err != nil || (constraints.MaxStops != nil && stops > *constraints.MaxStops) {
+			continue
+		}
+
+		recommendation := Recommendation{Starship: ship, Stops: stops}
+		if travel, err := TravelForShip(travelling, distance, stops, opts.DwellHours, opts.Strategy); err != nil {
+			recommendation.TravelError = err
+		} else {
+			recommendation.Travel = &travel
+		}
+		recommendations = append(recommendations, recommendation)
+	}
+
+	slices.SortFunc(recommendations, func(a, b Recommendation) int {
+		if a.Stops != b.Stops {
+			return cmp.Compare(a.Stops, b.Stops)
+		}
+		if a.totalHours() != b.totalHours() {
+			return cmp.Compare(a.totalHours(), b.totalHours())
+		}
+		return strings.Compare(a.Starship.Name, b.Starship.Name)
+	})
+
+	limit := constraints.Limit
+	if limit <= 0 {
+		limit = defaultRecommendations
+	}
+	return recommendations[:min(limit, len(recommendations))], nil
+}
+
+// allow reports whether a starship meets the passenger, cargo and class constraints.
+// Capacities are compared with the upper bound of the starship's count.
+func (c Constraints) allow(ship domain.Starship) bool {
+	if c.Class != "" && !strings.EqualFold(strings.TrimSpace(ship.Class), strings.TrimSpace(c.Class)) {
+		return false
+	}
+	if c.MinPassengers > 0 && (!ship.Passengers.Known || ship.Passengers.Max < c.MinPassengers) {
+		return false
+	}
+	if c.MinCargo > 0 && (!ship.CargoCapacity.Known || ship.CargoCapacity.Max < c.MinCargo) {
+		return false
+	}
+	return true
+}
+
+// totalHours returns the total travel hours of the recommendation, longer than any
+// representable trip when the travel time overflows
+func (r Recommendation) totalHours() int64 {
+	if r.Travel == nil {
+		return math.MaxInt64
+	}
+	return r.Travel.TotalHours
+}
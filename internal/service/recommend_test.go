@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/pvdevs/get-starships-stops/internal/domain"
+)
+
+// TestRecommender verifies ranking the starships able to cover a distance.
+// It tests scenarios including:
+// - Ranking by stops, then travel time, then name
+// - Passenger, cargo, class and stop constraints, unknown counts failing a minimum
+// - Limiting the number of recommendations
+func TestRecommender(t *testing.T) {
+	known := func(n int64) domain.Quantity { return domain.Quantity{Min: n, Max: n, Known: true} }
+	fleet := []domain.Starship{
+		{Name: "Millennium Falcon", MGLT: 75, Consumables: "2 months", Class: "Light freighter", Passengers: known(6), CargoCapacity: known(100000)},
+		{Name: "X-wing", MGLT: 100, Consumables: "1 week", Class: "Starfighter", Passengers: known(0), CargoCapacity: known(110)},
+		{Name: "Y-wing", MGLT: 80, Consumables: "1 week", Class: "assault starfighter", Passengers: known(0), CargoCapacity: known(110)},
+		{Name: "Star Destroyer", MGLT: 60, Consumables: "2 years", Class: "Star Destroyer", CargoCapacity: known(36000000)},
+		{Name: "Executor", UnknownMGLT: true, Consumables: "6 years", Class: "Star dreadnought", Passengers: known(38000)},
+	}
+	maxStops := func(n int64) *int64 { return &n }
+
+	tests := []struct {
+		name        string      // Test case description
+		distance    int64       // Distance to cover
+		constraints Constraints // Constraints to apply
+		wantNames   []string    // Expected starship names, in ranking order
+	}{
+		{
+			name:      "ranked by stops",
+			distance:  1000000,
+			wantNames: []string{"Star Destroyer", "Millennium Falcon", "X-wing", "Y-wing"},
+		},
+		{
+			name:      "same stops ranked by travel time",
+			distance:  10000,
+			wantNames: []string{"X-wing", "Y-wing", "Millennium Falcon", "Star Destroyer"},
+		},
+		{
+			name:        "passengers exclude unknown counts",
+			distance:    1000000,
+			constraints: Constraints{MinPassengers: 1},
+			wantNames:   []string{"Millennium Falcon"},
+		},
+		{
+			name:        "cargo and stops",
+			distance:    1000000,
+			constraints: Constraints{MinCargo: 100, MaxStops: maxStops(60)},
+			wantNames:   []string{"Star Destroyer", "Millennium Falcon", "X-wing"},
+		},
+		{
+			name:        "class ignoring case",
+			distance:    1000000,
+			constraints: Constraints{Class: "starfighter"},
+			wantNames:   []string{"X-wing"},
+		},
+		{
+			name:        "limit",
+			distance:    1000000,
+			constraints: Constraints{Limit: 2},
+			wantNames:   []string{"Star Destroyer", "Millennium Falcon"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recommender := NewRecommender(&mockStarshipClient{starships: fleet})
+			recommendations, err := recommender.Recommend(context.Background(), tt.distance, tt.constraints, Options{})
+			if err != nil {
+				t.Fatalf("Recommend() error = %v", err)
+			}
+
+			var names []string
+			for _, recommendation := range recommendations {
+				names = append(names, recommendation.Starship.Name)
+			}
+			if strings.Join(names, ",") != strings.Join(tt.wantNames, ",") {
+				t.Errorf("expected recommendations %v, got %v", tt.wantNames, names)
+			}
+		})
+	}
+}
+
+// TestHyperdriveRecommender verifies that hyperspace recommendations count stops at the
+// hyperspace speed, show the fetched starships and leave out those without a hyperdrive.
+func TestHyperdriveRecommender(t *testing.T) {
+	fleet := []domain.Starship{
+		{Name: "Millennium Falcon", MGLT: 75, Consumables: "2 months", HyperdriveRating: domain.Measure{Value: 0.5, Known: true}},
+		{Name: "X-wing", MGLT: 100, Consumables: "1 week", HyperdriveRating: domain.Measure{Value: 1, Known: true}},
+		{Name: "Star Destroyer", MGLT: 60, Consumables: "2 years"},
+	}
+
+	recommender := NewHyperdriveRecommender(&mockStarshipClient{starships: fleet})
+	recommendations, err := recommender.Recommend(context.Background(), 1000000, Constraints{}, Options{})
+	if err != nil {
+		t.Fatalf("Recommend() error = %v", err)
+	}
+	if len(recommendations) != 2 {
+		t.Fatalf("expected the starships without a hyperdrive left out, got %+v", recommendations)
+	}
+	// 150 MGLT × 1440 hours = 216000 per leg
+	falcon := recommendations[0]
+	if falcon.Starship.Name != "Millennium Falcon" || falcon.Stops != 4 || falcon.Starship.MGLT != 75 {
+		t.Errorf("expected the Millennium Falcon first with 4 stops and its sublight MGLT, got %+v", falcon)
+	}
+	if falcon.Travel == nil || falcon.Travel.Hours != 6667 {
+		t.Errorf("expected 6667 hours of hyperspace travel, got %+v", falcon.Travel)
+	}
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/pvdevs/get-starships-stops/internal/domain"
+)
+
+var (
+	ErrReserveTooLarge = errors.New("reserve exceeds autonomy")
+)
+
+// Reserve is the share of its consumables a starship keeps unused at every stop
+type Reserve struct {
+	Percent int64 // Share of the autonomy kept in reserve, from 0 to 99
+	Hours   int64 // Fixed duration kept in reserve, on top of Percent
+}
+
+// ReserveError reports requested starships whose autonomy doesn't cover the reserve.
+// It matches ErrReserveTooLarge and carries the starship names.
+type ReserveError struct {
+	Starships []string // Names of the starships left without autonomy
+}
+
+func (e *ReserveError) Error() string {
+	return fmt.Sprintf("reserve exceeds the autonomy of %s", strings.Join(e.Starships, ", "))
+}
+
+// Is makes errors.Is(err, ErrReserveTooLarge) match a ReserveError
+func (e *ReserveError) Is(target error) bool {
+	return target == ErrReserveTooLarge
+}
+
+// reserveFleet shortens the autonomy of every starship by the reserve, leaving out the
+// starships whose data can't be used and, as SkipReserveTooLarge, those whose autonomy
+// doesn't cover the reserve. Skipped starships keep their original data.
+func reserveFleet(starships []domain.Starship, reserve Reserve) ([]domain.Starship, []SkippedStarship) {
+	if reserve == (Reserve{}) {
+		return starships, nil
+	}
+
+	fleet := make([]domain.Starship, 0, len(starships))
+	var skipped []SkippedStarship
+	for _, ship := range starships {
+		reserved, err := ReservedStarship(ship, reserve)
+		if err != nil {
+			skipped = append(skipped, skip(ship, err))
+			continue
+		}
+		fleet = append(fleet, reserved)
+	}
+	return fleet, skipped
+}
+
+// ReservedStarship returns a copy of the starship keeping the reserve unused, its
+// ReserveHours increased by the reserve. The percentage is rounded up to a whole hour.
+// Returns the StopsForShip errors for unusable data, and ErrReserveTooLarge when no
+// autonomy is left.
+func ReservedStarship(ship domain.Starship, reserve Reserve) (domain.Starship, error) {
+	hours, _, err := shipRange(ship)
+	if err != nil && !errors.Is(err, ErrOverflow) {
+		return domain.Starship{}, err
+	}
+
+	// Split the multiplication so that it can't overflow
+	percent := min(max(reserve.Percent, 0), 99)
+	reserved := hours/100*percent + (hours%100*percent+99)/100
+	if reserve.Hours >= hours-reserved {
+		return domain.Starship{}, fmt.Errorf("autonomy of %s: %w", ship.Name, ErrReserveTooLarge)
+	}
+
+	ship.ReserveHours += reserved + reserve.Hours
+	return ship, nil
+}
@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/pvdevs/get-starships-stops/internal/domain"
+)
+
+// TestReservedStarship verifies the autonomy left to a starship once its reserve is set aside.
+// It tests scenarios including:
+// - Percentages rounded up to a whole hour, fixed durations and both combined
+// - Reserves leaving no autonomy
+// - Starships with unusable data
+func TestReservedStarship(t *testing.T) {
+	xwing := domain.Starship{Name: "X-wing", MGLT: 100, Consumables: "1 week"} // 168 hours
+
+	tests := []struct {
+		name         string          // Test case description
+		ship         domain.Starship // Starship keeping a reserve
+		reserve      Reserve         // Reserve to set aside
+		wantAutonomy int64           // Expected hours of autonomy left
+		wantErr      error           // Expected error
+	}{
+		{name: "percentage", ship: xwing, reserve: Reserve{Percent: 15}, wantAutonomy: 142},
+		{name: "duration", ship: xwing, reserve: Reserve{Hours: 24}, wantAutonomy: 144},
+		{name: "both", ship: xwing, reserve: Reserve{Percent: 50, Hours: 24}, wantAutonomy: 60},
+		{name: "whole autonomy", ship: xwing, reserve: Reserve{Hours: 168}, wantErr: ErrReserveTooLarge},
+		{name: "percentage rounded to the whole autonomy", ship: domain.Starship{Name: "Pod", MGLT: 10, Consumables: "1 day"}, reserve: Reserve{Percent: 99}, wantErr: ErrReserveTooLarge},
+		{name: "unknown MGLT", ship: domain.Starship{Name: "Executor", UnknownMGLT: true, Consumables: "6 years"}, reserve: Reserve{Hours: 24}, wantErr: ErrUnknownMGLT},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReservedStarship(tt.ship, tt.reserve)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ReservedStarship() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got.Consumables != tt.ship.Consumables {
+				t.Errorf("ReservedStarship() changed consumables to %q", got.Consumables)
+			}
+			if autonomy, _, _ := shipRange(got); autonomy != tt.wantAutonomy {
+				t.Errorf("ReservedStarship() autonomy = %d hours, want %d", autonomy, tt.wantAutonomy)
+			}
+		})
+	}
+}
+
+// TestCalculator_reserve verifies that the calculators count stops on the autonomy left by
+// the reserve, keep skipped starships intact and skip the starships that can't keep it.
+func TestCalculator_reserve(t *testing.T) {
+	client := &mockStarshipClient{starships: []domain.Starship{
+		{Name: "Millennium Falcon", MGLT: 75, Consumables: "2 months", HyperdriveRating: domain.Measure{Value: 0.5, Known: true}},
+		{Name: "X-wing", MGLT: 100, Consumables: "1 week", HyperdriveRating: domain.Measure{Value: 1, Known: true}},
+		{Name: "Executor", UnknownMGLT: true, Consumables: "6 years"},
+	}}
+
+	calculation, err := NewCalculator(client).CalculateStops(context.Background(), 1000000, Options{Reserve: Reserve{Hours: 24}})
+	if err != nil {
+		t.Fatalf("CalculateStops() error = %v", err)
+	}
+	// 1416 hours × 75 MGLT = 106200 per leg, 144 hours × 100 MGLT = 14400 per leg
+	if calculation.Stops["Millennium Falcon"] != 9 || calculation.Stops["X-wing"] != 69 {
+		t.Errorf("unexpected stops %v", calculation.Stops)
+	}
+	if travel := calculation.Travel["X-wing"]; travel.LegHours != 144 {
+		t.Errorf("expected 144 hours per leg, got %+v", travel)
+	}
+	if len(calculation.Skipped) != 1 || calculation.Skipped[0].Starship.Consumables != "6 years" {
+		t.Errorf("expected the Executor skipped with its original data, got %+v", calculation.Skipped)
+	}
+
+	batch, err := NewHyperdriveCalculator(client).CalculateStopsBatch(context.Background(), []int64{1000000}, Options{Reserve: Reserve{Percent: 50}})
+	if err != nil {
+		t.Fatalf("CalculateStopsBatch() error = %v", err)
+	}
+	// 720 hours × 150 MGLT = 108000 per leg
+	if batch[0].Stops["Millennium Falcon"] != 9 {
+		t.Errorf("unexpected hyperspace stops %v", batch[0].Stops)
+	}
+
+	for _, calculator := range []CalculatorService{NewCalculator(client), NewHyperdriveCalculator(client)} {
+		calculation, err = calculator.CalculateStops(context.Background(), 1000000, Options{Reserve: Reserve{Hours: 168}})
+		if err != nil {
+			t.Fatalf("CalculateStops() error = %v", err)
+		}
+		if _, ok := calculation.Stops["Millennium Falcon"]; !ok || len(calculation.Stops) != 1 {
+			t.Errorf("expected only the Millennium Falcon to keep the reserve, got %v", calculation.Stops)
+		}
+		reasons := make(map[string]string, len(calculation.Skipped))
+		for _, skipped := range calculation.Skipped {
+			reasons[skipped.Starship.Name] = skipped.Reason
+		}
+		if reasons["X-wing"] != SkipReserveTooLarge || reasons["Executor"] != SkipUnknownMGLT {
+			t.Errorf("expected the X-wing skipped as %s, got %v", SkipReserveTooLarge, reasons)
+		}
+	}
+}
+
+// TestReserve_rangesAndRecommendations verifies that maximum ranges and recommendations
+// use the autonomy left by the reserve, leaving out the starships that can't keep it.
+func TestReserve_rangesAndRecommendations(t *testing.T) {
+	client := &mockStarshipClient{starships: []domain.Starship{
+		{Name: "Millennium Falcon", MGLT: 75, Consumables: "2 months", HyperdriveRating: domain.Measure{Value: 0.5, Known: true}},
+		{Name: "X-wing", MGLT: 100, Consumables: "1 week", HyperdriveRating: domain.Measure{Value: 1, Known: true}},
+	}}
+	opts := Options{Reserve: Reserve{Hours: 168}}
+
+	ranges, err := NewCalculator(client).MaxRanges(context.Background(), 1, opts)
+	if err != nil {
+		t.Fatalf("MaxRanges() error = %v", err)
+	}
+	// 1272 hours × 75 MGLT × 2 legs
+	if len(ranges.Ranges) != 1 || ranges.Ranges["Millennium Falcon"] != 190800 {
+		t.Errorf("expected only the Millennium Falcon to cover 190800 MGLT, got %v", ranges.Ranges)
+	}
+	if len(ranges.Skipped) != 1 || ranges.Skipped[0].Reason != SkipReserveTooLarge {
+		t.Errorf("expected the X-wing skipped as %s, got %+v", SkipReserveTooLarge, ranges.Skipped)
+	}
+
+	hyperspace, err := NewHyperdriveCalculator(client).MaxRanges(context.Background(), 0, opts)
+	if err != nil {
+		t.Fatalf("MaxRanges() error = %v", err)
+	}
+	// 1272 hours × 150 MGLT
+	if len(hyperspace.Ranges) != 1 || hyperspace.Ranges["Millennium Falcon"] != 190800 {
+		t.Errorf("expected only the Millennium Falcon to cover 190800 MGLT through hyperspace, got %v", hyperspace.Ranges)
+	}
+
+	recommendations, err := NewRecommender(client).Recommend(context.Background(), 1000000, Constraints{}, opts)
+	if err != nil {
+		t.Fatalf("Recommend() error = %v", err)
+	}
+	// 1000000 / 95400 MGLT per leg
+	if len(recommendations) != 1 || recommendations[0].Starship.Name != "Millennium Falcon" || recommendations[0].Stops != 10 {
+		t.Fatalf("expected only the Millennium Falcon with 10 stops, got %+v", recommendations)
+	}
+	if travel := recommendations[0].Travel; travel == nil || travel.LegHours != 1272 {
+		t.Errorf("expected 1272 hours per leg, got %+v", travel)
+	}
+}
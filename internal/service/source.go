@@ -0,0 +1,40 @@
+package service
+
+import (
+	"context"
+	"sync"
+)
+
+// sourceKey is the context key under which a SourceRecorder is stored
+type sourceKey struct{}
+
+// SourceRecorder captures the name of the source that answered a starship fetch.
+// Clients that know where their data came from report it with RecordSource.
+type SourceRecorder struct {
+	mu   sync.Mutex
+	name string
+}
+
+// WithSourceRecorder returns a context carrying a new recorder, and the recorder itself
+func WithSourceRecorder(ctx context.Context) (context.Context, *SourceRecorder) {
+	recorder := &SourceRecorder{}
+	return context.WithValue(ctx, sourceKey{}, recorder), recorder
+}
+
+// Source returns the recorded source name, or an empty string if none was reported
+func (r *SourceRecorder) Source() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.name
+}
+
+// RecordSource reports the source that answered to the recorder carried by ctx, if any
+func RecordSource(ctx context.Context, name string) {
+	recorder, ok := ctx.Value(sourceKey{}).(*SourceRecorder)
+	if !ok || name == "" {
+		return
+	}
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	recorder.name = name
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pvdevs/get-starships-stops/internal/domain"
+)
+
+var (
+	ErrNoStoredFleet = errors.New("no stored fleet")
+)
+
+const (
+	fleetFileName = "fleet.json"
+)
+
+// StoredFleet is a fleet persisted by a FleetStore, along with when and where it was fetched
+type StoredFleet struct {
+	Starships []domain.Starship `json:"starships"`
+	Source    string            `json:"source,omitempty"`
+	FetchedAt time.Time         `json:"fetched_at"`
+}
+
+// FleetStore persists the cached fleet so it survives restarts
+type FleetStore interface {
+	Load() (StoredFleet, error)
+	Save(fleet StoredFleet) error
+}
+
+// FileStore is a FleetStore keeping the fleet in a JSON file inside a directory
+type FileStore struct {
+	path string
+}
+
+// NewFileStore creates a FileStore writing to fleet.json inside dir
+func NewFileStore(dir string) *FileStore {
+	return &FileStore{
+		path: filepath.Join(dir, fleetFileName),
+	}
+}
+
+// Load reads the stored fleet, returning ErrNoStoredFleet if none was saved yet
+func (s *FileStore) Load() (StoredFleet, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return StoredFleet{}, ErrNoStoredFleet
+	}
+	if err != nil {
+		return StoredFleet{}, fmt.Errorf("read fleet: %w", err)
+	}
+
+	var fleet StoredFleet
+	if err := json.Unmarshal(data, &fleet); err != nil {
+		return StoredFleet{}, fmt.Errorf("decode fleet %s: %w", s.path, err)
+	}
+	return fleet, nil
+}
+
+// Save writes the fleet atomically, so a crash never leaves a truncated file behind
+func (s *FileStore) Save(fleet StoredFleet) error {
+	data, err := json.Marshal(fleet)
+	if err != nil {
+		return fmt.Errorf("encode fleet: %w", err)
+	}
+
+	dir := filepath.Dir(s.path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("create cache directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(dir, ".fleet-*.json")
+	if err != nil {
+		return fmt.Errorf("create fleet file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write fleet: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write fleet: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("save fleet: %w", err)
+	}
+	return nil
+}
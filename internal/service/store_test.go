@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/pvdevs/get-starships-stops/internal/domain"
+)
+
+// TestFileStore verifies that a saved fleet can be loaded back, and that a missing
+// file is reported as ErrNoStoredFleet.
+func TestFileStore(t *testing.T) {
+	store := NewFileStore(filepath.Join(t.TempDir(), "cache"))
+
+	if _, err := store.Load(); !errors.Is(err, ErrNoStoredFleet) {
+		t.Fatalf("expected ErrNoStoredFleet before saving, got %v", err)
+	}
+
+	fleet := StoredFleet{
+		Starships: []domain.Starship{{
+			Name: "X-wing", MGLT: 100, Consumables: "1 week", Manufacturers: []string{"Incom Corporation"},
+			Length: domain.Measure{Value: 12.5, Known: true}, Crew: domain.Quantity{Min: 1, Max: 1, Known: true},
+		}},
+		Source:    "swapi.dev",
+		FetchedAt: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+	}
+	if err := store.Save(fleet); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	got, err := store.Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if !reflect.DeepEqual(got.Starships, fleet.Starships) {
+		t.Errorf("expected starships %+v, got %+v", fleet.Starships, got.Starships)
+	}
+	if got.Source != fleet.Source || !got.FetchedAt.Equal(fleet.FetchedAt) {
+		t.Errorf("expected source %q fetched at %s, got %q at %s", fleet.Source, fleet.FetchedAt, got.Source, got.FetchedAt)
+	}
+}
+
+// TestCachedClient_warmStart verifies that a cache backed by a store starts from the
+// stored fleet, serving it even when the upstream is down, and persists new fetches.
+func TestCachedClient_warmStart(t *testing.T) {
+	store := NewFileStore(t.TempDir())
+	if err := store.Save(StoredFleet{
+		Starships: []domain.Starship{{Name: "X-wing", MGLT: 100, Consumables: "1 week"}},
+		FetchedAt: time.Now().Add(-time.Hour),
+	}); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	upstream := &countingClient{err: errors.New("upstream down"), fetched: make(chan struct{}, 4)}
+	cache := NewCachedClient(upstream, CacheConfig{TTL: 5 * time.Minute, Store: store})
+
+	// The stored fleet is expired, so a background refresh starts right away
+	select {
+	case <-upstream.fetched:
+	case <-time.After(time.Second):
+		t.Fatal("expected a background refresh of the expired stored fleet")
+	}
+
+	starships, err := cache.GetStarships(context.Background())
+	if err != nil {
+		t.Fatalf("GetStarships() error = %v", err)
+	}
+	if len(starships) != 1 || starships[0].Name != "X-wing" {
+		t.Errorf("expected stored X-wing while upstream is down, got %+v", starships)
+	}
+	<-upstream.fetched
+
+	upstream.set([]domain.Starship{{Name: "Y-wing", MGLT: 80, Consumables: "1 week"}}, nil)
+	if _, err := cache.GetStarships(context.Background()); err != nil {
+		t.Fatalf("GetStarships() error = %v", err)
+	}
+	<-upstream.fetched
+
+	stored, err := store.Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(stored.Starships) != 1 || stored.Starships[0].Name != "Y-wing" {
+		t.Errorf("expected fetched Y-wing to be persisted, got %+v", stored.Starships)
+	}
+}
+
+// recordingStore is a FleetStore keeping every saved fleet in memory
+type recordingStore struct {
+	saved []StoredFleet
+}
+
+func (s *recordingStore) Load() (StoredFleet, error) {
+	return StoredFleet{}, ErrNoStoredFleet
+}
+
+func (s *recordingStore) Save(fleet StoredFleet) error {
+	s.saved = append(s.saved, fleet)
+	return nil
+}
+
+// TestCachedClient_saveOrder verifies that a fleet fetched before the last persisted one
+// is not saved over it, as happens when two refreshes reach the store out of order.
+func TestCachedClient_saveOrder(t *testing.T) {
+	store := &recordingStore{}
+	cache := NewCachedClient(&mockStarshipClient{}, CacheConfig{Store: store})
+
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+	cache.store([]domain.Starship{{Name: "Y-wing", MGLT: 80, Consumables: "1 week"}}, "newer")
+
+	cache.now = func() time.Time { return now.Add(-time.Second) }
+	cache.store([]domain.Starship{{Name: "X-wing", MGLT: 100, Consumables: "1 week"}}, "older")
+
+	if len(store.saved) != 1 || store.saved[0].Source != "newer" {
+		t.Errorf("expected only the newer fleet to be persisted, got %+v", store.saved)
+	}
+}
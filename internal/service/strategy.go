@@ -0,0 +1,164 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/pvdevs/get-starships-stops/internal/domain"
+)
+
+var (
+	ErrUnknownStrategy = errors.New("unknown stop strategy")
+)
+
+// Names of the built-in stop strategies
+const (
+	StrategyExact   = "exact"   // Floor division, an exact multiple of the range needs one stop less
+	StrategyCeiling = "ceiling" // Stop before running dry, an exact multiple of the range needs a stop on arrival
+	StrategyMargin  = "margin"  // Exact rule on the range shortened by a safety margin
+)
+
+const (
+	DefaultMarginPercent = 10 // Safety margin of the registered margin strategy
+)
+
+// StopStrategy turns a starship and a distance into the number of stops it needs.
+// Strategies return the StopsForShip errors for starships whose data can't be used.
+type StopStrategy interface {
+	Stops(ship domain.Starship, distance int64) (int64, error)
+}
+
+// LegStrategy is implemented by stop strategies whose legs are shorter than the autonomy
+// of a starship, so that travel times report the legs the stops were counted with
+type LegStrategy interface {
+	LegHours(ship domain.Starship) (int64, error)
+}
+
+// StopStrategyFunc adapts a function to a StopStrategy
+type StopStrategyFunc func(ship domain.Starship, distance int64) (int64, error)
+
+// Stops calls f(ship, distance)
+func (f StopStrategyFunc) Stops(ship domain.Starship, distance int64) (int64, error) {
+	return f(ship, distance)
+}
+
+var (
+	strategiesMu sync.RWMutex
+	strategies   = map[string]StopStrategy{
+		StrategyExact:   StopStrategyFunc(StopsForShip),
+		StrategyCeiling: StopStrategyFunc(ceilingStops),
+		StrategyMargin:  MarginStrategy{Percent: DefaultMarginPercent},
+	}
+)
+
+// RegisterStrategy makes a stop strategy available to requests under a name.
+// It panics if the name is already taken, as that is always a programming error.
+func RegisterStrategy(name string, strategy StopStrategy) {
+	strategiesMu.Lock()
+	defer strategiesMu.Unlock()
+	if _, exists := strategies[name]; exists {
+		panic(fmt.Sprintf("service: stop strategy %q registered twice", name))
+	}
+	strategies[name] = strategy
+}
+
+// LookupStrategy returns the stop strategy registered under a name
+func LookupStrategy(name string) (StopStrategy, error) {
+	strategiesMu.RLock()
+	strategy, ok := strategies[name]
+	strategiesMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w %q, expected one of %s", ErrUnknownStrategy, name, strings.Join(StrategyNames(), ", "))
+	}
+	return strategy, nil
+}
+
+// StrategyNames returns the names of every registered stop strategy, sorted
+func StrategyNames() []string {
+	strategiesMu.RLock()
+	defer strategiesMu.RUnlock()
+	names := make([]string, 0, len(strategies))
+	for name := range strategies {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// ceilingStops counts the stops of a starship that resupplies before its consumables
+// run out, so every leg must be strictly shorter than its range
+func ceilingStops(ship domain.Starship, distance int64) (int64, error) {
+	_, maxDistance, err := shipRange(ship)
+	if errors.Is(err, ErrOverflow) {
+		return 0, nil // The range exceeds any int64 distance
+	}
+	if err != nil {
+		return 0, err
+	}
+	return distance / maxDistance, nil
+}
+
+// MarginStrategy applies the exact rule to a range shortened by a safety margin, so a
+// starship arrives at every stop with Percent of its consumables left
+type MarginStrategy struct {
+	Percent int64 // Share of the range kept as margin, from 0 to 99
+}
+
+// Stops counts the stops of a starship travelling at most 100 - Percent of its range per leg
+func (m MarginStrategy) Stops(ship domain.Starship, distance int64) (int64, error) {
+	hours, _, err := shipRange(ship)
+	if err != nil && !errors.Is(err, ErrOverflow) {
+		return 0, err
+	}
+
+	legDistance, ok := marginRange(int64(ship.MGLT), hours, m.usable())
+	if !ok {
+		return 0, nil // The shortened range still exceeds any int64 distance
+	}
+
+	stops := distance / legDistance
+	if distance%legDistance == 0 && stops > 0 {
+		stops--
+	}
+	return stops, nil
+}
+
+// LegHours returns the hours a starship takes to travel 100 - Percent of its range, the
+// longest leg between two stops
+func (m MarginStrategy) LegHours(ship domain.Starship) (int64, error) {
+	hours, _, err := shipRange(ship)
+	if err != nil && !errors.Is(err, ErrOverflow) {
+		return 0, err
+	}
+
+	mglt := int64(ship.MGLT)
+	legDistance, ok := marginRange(mglt, hours, m.usable())
+	if !ok {
+		return hours, nil
+	}
+	legHours := legDistance / mglt
+	if legDistance%mglt != 0 {
+		legHours++
+	}
+	return legHours, nil
+}
+
+// usable returns the share of the range travelled per leg, from 1 to 100
+func (m MarginStrategy) usable() int64 {
+	return 100 - min(max(m.Percent, 0), 99)
+}
+
+// marginRange returns mglt × hours × usable / 100, at least 1, computed without overflow.
+// It returns false when the result doesn't fit in an int64.
+func marginRange(mglt, hours, usable int64) (int64, bool) {
+	legDistance := new(big.Int).Mul(big.NewInt(mglt), big.NewInt(hours))
+	legDistance.Mul(legDistance, big.NewInt(usable)).Quo(legDistance, big.NewInt(100))
+	if !legDistance.IsInt64() {
+		return 0, false
+	}
+	return max(legDistance.Int64(), 1), true
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/pvdevs/get-starships-stops/internal/domain"
+)
+
+// unregisterStrategy removes a strategy registered by a test, so that the test can run again
+func unregisterStrategy(name string) {
+	strategiesMu.Lock()
+	defer strategiesMu.Unlock()
+	delete(strategies, name)
+}
+
+// TestStopStrategies verifies the stops counted by the built-in strategies.
+// It tests scenarios including:
+// - Distances below, at and above a multiple of the range
+// - The margin strategy shortening the range
+// - Ranges overflowing an int64 and starships with unusable data
+func TestStopStrategies(t *testing.T) {
+	xwing := domain.Starship{Name: "X-wing", MGLT: 100, Consumables: "1 week"} // 16800 MGLT per leg, 15120 with a 10% margin
+	huge := domain.Starship{Name: "Huge", MGLT: math.MaxInt32, Consumables: "1000000000 years"}
+
+	tests := []struct {
+		name     string          // Test case description
+		strategy string          // Registered strategy name
+		ship     domain.Starship // Starship travelling
+		distance int64           // Distance to cover
+		want     int64           // Expected stops
+		wantErr  error           // Expected error
+	}{
+		{name: "exact below range", strategy: StrategyExact, ship: xwing, distance: 16799, want: 0},
+		{name: "exact at range", strategy: StrategyExact, ship: xwing, distance: 16800, want: 0},
+		{name: "exact above range", strategy: StrategyExact, ship: xwing, distance: 16801, want: 1},
+		{name: "ceiling below range", strategy: StrategyCeiling, ship: xwing, distance: 16799, want: 0},
+		{name: "ceiling at range", strategy: StrategyCeiling, ship: xwing, distance: 16800, want: 1},
+		{name: "ceiling long distance", strategy: StrategyCeiling, ship: xwing, distance: 1000000, want: 59},
+		{name: "margin at shortened range", strategy: StrategyMargin, ship: xwing, distance: 15120, want: 0},
+		{name: "margin above shortened range", strategy: StrategyMargin, ship: xwing, distance: 16800, want: 1},
+		{name: "margin long distance", strategy: StrategyMargin, ship: xwing, distance: 1000000, want: 66},
+		{name: "ceiling overflowing range", strategy: StrategyCeiling, ship: huge, distance: math.MaxInt64, want: 0},
+		{name: "margin overflowing range", strategy: StrategyMargin, ship: huge, distance: math.MaxInt64, want: 0},
+		{name: "margin zero speed", strategy: StrategyMargin, ship: domain.Starship{Name: "Death Star", Consumables: "3 years"}, wantErr: ErrZeroSpeed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			strategy, err := LookupStrategy(tt.strategy)
+			if err != nil {
+				t.Fatalf("LookupStrategy(%q) error = %v", tt.strategy, err)
+			}
+			got, err := strategy.Stops(tt.ship, tt.distance)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Stops() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Stops() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestRegisterStrategy verifies that registered strategies can be looked up, are used by
+// the calculator, and that unknown or duplicate names are rejected.
+func TestRegisterStrategy(t *testing.T) {
+	RegisterStrategy("test-fixed", StopStrategyFunc(func(ship domain.Starship, distance int64) (int64, error) {
+		return 7, nil
+	}))
+	t.Cleanup(func() { unregisterStrategy("test-fixed") })
+
+	if names := StrategyNames(); !slices.Contains(names, "test-fixed") || !slices.IsSorted(names) {
+		t.Errorf("expected sorted names with the registered strategy, got %v", names)
+	}
+	if _, err := LookupStrategy("unknown"); !errors.Is(err, ErrUnknownStrategy) {
+		t.Errorf("expected ErrUnknownStrategy, got %v", err)
+	}
+
+	strategy, err := LookupStrategy("test-fixed")
+	if err != nil {
+		t.Fatalf("LookupStrategy() error = %v", err)
+	}
+	calculator := NewCalculator(&mockStarshipClient{starships: []domain.Starship{{Name: "X-wing", MGLT: 100, Consumables: "1 week"}}})
+	calculation, err := calculator.CalculateStops(context.Background(), 1000, Options{Strategy: strategy})
+	if err != nil {
+		t.Fatalf("CalculateStops() error = %v", err)
+	}
+	if calculation.Stops["X-wing"] != 7 {
+		t.Errorf("expected the registered strategy to count 7 stops, got %v", calculation.Stops)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected registering a taken name to panic")
+		}
+	}()
+	RegisterStrategy(StrategyExact, strategy)
+}
+
+// TestStopStrategy_error verifies that a custom strategy failing with an error of its own
+// leaves the starship out with the strategy_error reason, while the other reasons still
+// come from the built-in checks.
+func TestStopStrategy_error(t *testing.T) {
+	client := &mockStarshipClient{starships: []domain.Starship{
+		{Name: "X-wing", MGLT: 100, Consumables: "1 week"},
+		{Name: "Y-wing", MGLT: 80, Consumables: "1 week"},
+		{Name: "Broken", MGLT: 10, Consumables: "forever"},
+	}}
+	strategy := StopStrategyFunc(func(ship domain.Starship, distance int64) (int64, error) {
+		if ship.Name == "Y-wing" {
+			return 0, errors.New("no route")
+		}
+		return StopsForShip(ship, distance)
+	})
+
+	calculation, err := NewCalculator(client).CalculateStops(context.Background(), 1000000, Options{Strategy: strategy})
+	if err != nil {
+		t.Fatalf("CalculateStops() error = %v", err)
+	}
+	if _, ok := calculation.Stops["X-wing"]; !ok || len(calculation.Stops) != 1 {
+		t.Errorf("expected only the X-wing to have stops, got %v", calculation.Stops)
+	}
+
+	reasons := make(map[string]SkippedStarship, len(calculation.Skipped))
+	for _, skipped := range calculation.Skipped {
+		reasons[skipped.Starship.Name] = skipped
+	}
+	if got := reasons["Y-wing"]; got.Reason != SkipStrategyError || got.Detail != "no route" {
+		t.Errorf("expected the Y-wing skipped as %s with detail %q, got %+v", SkipStrategyError, "no route", got)
+	}
+	if got := reasons["Broken"]; got.Reason != SkipInvalidConsumables {
+		t.Errorf("expected the Broken starship skipped as %s, got %+v", SkipInvalidConsumables, got)
+	}
+}
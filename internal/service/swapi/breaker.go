@@ -0,0 +1,199 @@
+package swapi
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+var (
+	ErrCircuitOpen = errors.New("SWAPI circuit breaker is open")
+)
+
+const (
+	defaultBreakerWindow      = 20
+	defaultBreakerMinRequests = 5
+	defaultBreakerOpenTimeout = 30 * time.Second
+)
+
+// BreakerState describes whether requests are currently allowed through to SWAPI
+type BreakerState int
+
+const (
+	BreakerClosed   BreakerState = iota // Requests flow normally
+	BreakerOpen                         // Requests fail fast without reaching SWAPI
+	BreakerHalfOpen                     // A single probe request is allowed through
+)
+
+// String returns the lowercase name of the state
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// BreakerConfig controls when the circuit breaker opens and how it recovers
+type BreakerConfig struct {
+	FailureRate float64       // Fraction of failed requests in the window that opens the breaker, 0 disables it
+	Window      int           // Number of most recent requests the failure rate is computed over
+	MinRequests int           // Requests needed in the window before the breaker may open
+	OpenTimeout time.Duration // How long the breaker stays open before probing SWAPI again
+}
+
+// breaker is a failure-rate circuit breaker over a sliding window of request outcomes
+type breaker struct {
+	failureRate float64
+	minRequests int
+	openTimeout time.Duration
+	now         func() time.Time
+
+	mu       sync.Mutex
+	state    BreakerState
+	outcomes []bool // Ring buffer of recent outcomes, true meaning failure
+	next     int
+	count    int
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+// newBreaker creates a breaker from the config, or returns nil when it is disabled
+func newBreaker(config BreakerConfig) *breaker {
+	if config.FailureRate <= 0 {
+		return nil
+	}
+	if config.Window <= 0 {
+		config.Window = defaultBreakerWindow
+	}
+	if config.MinRequests <= 0 {
+		config.MinRequests = min(defaultBreakerMinRequests, config.Window)
+	}
+	if config.OpenTimeout <= 0 {
+		config.OpenTimeout = defaultBreakerOpenTimeout
+	}
+	return &breaker{
+		failureRate: config.FailureRate,
+		minRequests: config.MinRequests,
+		openTimeout: config.OpenTimeout,
+		now:         time.Now,
+		outcomes:    make([]bool, config.Window),
+	}
+}
+
+// allow reports whether a request may be sent, returning ErrCircuitOpen when it may not.
+// Once the open timeout has passed, a single probe is let through in half-open state.
+func (b *breaker) allow() error {
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case BreakerOpen:
+		if b.now().Sub(b.openedAt) < b.openTimeout {
+			return ErrCircuitOpen
+		}
+		b.state = BreakerHalfOpen
+		b.probing = true
+		return nil
+	case BreakerHalfOpen:
+		if b.probing {
+			return ErrCircuitOpen
+		}
+		b.probing = true
+		return nil
+	default:
+		return nil
+	}
+}
+
+// record registers the outcome of a request previously allowed through
+func (b *breaker) record(failed bool) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == BreakerHalfOpen {
+		b.probing = false
+		if failed {
+			b.open()
+		} else {
+			b.reset()
+		}
+		return
+	}
+
+	if b.outcomes[b.next] {
+		b.failures--
+	}
+	b.outcomes[b.next] = failed
+	if failed {
+		b.failures++
+	}
+	b.next = (b.next + 1) % len(b.outcomes)
+	b.count = min(b.count+1, len(b.outcomes))
+
+	if b.state == BreakerClosed && b.count >= b.minRequests &&
+		float64(b.failures)/float64(b.count) >= b.failureRate {
+		b.open()
+	}
+}
+
+// release gives back a probe slot without recording an outcome,
+// used when the caller gave up before SWAPI answered
+func (b *breaker) release() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+}
+
+// State returns the current state, reporting an expired open breaker as half-open
+func (b *breaker) State() BreakerState {
+	if b == nil {
+		return BreakerClosed
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == BreakerOpen && b.now().Sub(b.openedAt) >= b.openTimeout {
+		return BreakerHalfOpen
+	}
+	return b.state
+}
+
+// isUpstreamFailure reports whether an error counts against SWAPI's health.
+// Client errors other than rate limiting mean SWAPI answered properly and are not counted.
+func isUpstreamFailure(err error) bool {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode >= http.StatusInternalServerError ||
+			statusErr.StatusCode == http.StatusTooManyRequests
+	}
+	return true
+}
+
+// open trips the breaker; callers must hold mu
+func (b *breaker) open() {
+	b.state = BreakerOpen
+	b.openedAt = b.now()
+}
+
+// reset closes the breaker and clears the window; callers must hold mu
+func (b *breaker) reset() {
+	b.state = BreakerClosed
+	clear(b.outcomes)
+	b.next, b.count, b.failures = 0, 0, 0
+}
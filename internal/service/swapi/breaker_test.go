@@ -0,0 +1,95 @@
+package swapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestBreaker verifies the state transitions of the circuit breaker.
+// It ensures that:
+// - The breaker stays closed below the minimum number of requests
+// - The breaker opens once the failure rate is reached
+// - An open breaker fails fast until the open timeout passes
+// - A successful half-open probe closes the breaker, a failed one reopens it
+func TestBreaker(t *testing.T) {
+	now := time.Now()
+	b := newBreaker(BreakerConfig{FailureRate: 0.5, Window: 4, MinRequests: 4, OpenTimeout: time.Minute})
+	b.now = func() time.Time { return now }
+
+	for _, failed := range []bool{true, false, true} {
+		if err := b.allow(); err != nil {
+			t.Fatalf("allow() error = %v while closed", err)
+		}
+		b.record(failed)
+	}
+	if state := b.State(); state != BreakerClosed {
+		t.Fatalf("expected closed below min requests, got %s", state)
+	}
+
+	b.record(true)
+	if state := b.State(); state != BreakerOpen {
+		t.Fatalf("expected open after failure rate reached, got %s", state)
+	}
+	if err := b.allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected ErrCircuitOpen while open, got %v", err)
+	}
+
+	now = now.Add(time.Minute)
+	if err := b.allow(); err != nil {
+		t.Fatalf("expected probe to be allowed after open timeout, got %v", err)
+	}
+	if err := b.allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected second request to be rejected during probe, got %v", err)
+	}
+	b.record(true)
+	if state := b.State(); state != BreakerOpen {
+		t.Fatalf("expected failed probe to reopen breaker, got %s", state)
+	}
+
+	now = now.Add(time.Minute)
+	if err := b.allow(); err != nil {
+		t.Fatalf("expected probe to be allowed after open timeout, got %v", err)
+	}
+	b.record(false)
+	if state := b.State(); state != BreakerClosed {
+		t.Fatalf("expected successful probe to close breaker, got %s", state)
+	}
+}
+
+// TestClient_breakerFailsFast verifies that once the breaker opens, the client stops
+// sending requests to SWAPI and reports ErrCircuitOpen.
+func TestClient_breakerFailsFast(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client := NewClient(ClientConfig{
+		BaseURL: server.URL,
+		Breaker: BreakerConfig{FailureRate: 0.5, Window: 2, MinRequests: 2, OpenTimeout: time.Minute},
+	})
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetStarships(context.Background()); err == nil {
+			t.Fatal("expected error from failing server")
+		}
+	}
+
+	_, err := client.GetStarships(context.Background())
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("expected ErrCircuitOpen, got %v", err)
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("expected 2 requests to reach SWAPI, got %d", got)
+	}
+	if state := client.BreakerState(); state != BreakerOpen {
+		t.Errorf("expected breaker to be open, got %s", state)
+	}
+}
@@ -3,28 +3,36 @@ package swapi
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/pvdevs/get-starships-stops/internal/domain"
-)
-
-var (
-	ErrSkipShip = fmt.Errorf("skip ship")
+	"github.com/pvdevs/get-starships-stops/internal/parser"
 )
 
 // Client handles all communication with the SWAPI API
 type Client struct {
-	baseURL    string
-	httpClient *http.Client
+	baseURL        string
+	httpClient     *http.Client
+	maxRetries     int
+	retryBaseDelay time.Duration
+	retryMaxDelay  time.Duration
+	breaker        *breaker
+	concurrency    int
+	pages          pageStore
 }
 
 type ClientConfig struct {
-	BaseURL string
-	Timeout time.Duration
+	BaseURL        string
+	Timeout        time.Duration
+	MaxRetries     int           // Retries for a failed page fetch, 0 disables retrying
+	RetryBaseDelay time.Duration // Backoff before the first retry, doubled on every attempt
+	RetryMaxDelay  time.Duration // Upper bound for a single backoff, Retry-After included
+	Breaker        BreakerConfig // Circuit breaker settings, disabled when FailureRate is 0
+	Concurrency    int           // Maximum number of pages fetched at the same time
 }
 
 // NewClient creates a new SWAPI client instance
@@ -32,69 +40,157 @@ func NewClient(config ClientConfig) *Client {
 	if config.Timeout == 0 {
 		config.Timeout = 10 * time.Second // default timeout
 	}
+	if config.RetryBaseDelay <= 0 {
+		config.RetryBaseDelay = defaultRetryBaseDelay
+	}
+	if config.RetryMaxDelay <= 0 {
+		config.RetryMaxDelay = defaultRetryMaxDelay
+	}
+	if config.Concurrency <= 0 {
+		config.Concurrency = defaultConcurrency
+	}
 	return &Client{
 		baseURL: config.BaseURL,
 		httpClient: &http.Client{
 			Timeout: config.Timeout,
 		},
+		maxRetries:     max(config.MaxRetries, 0),
+		retryBaseDelay: config.RetryBaseDelay,
+		retryMaxDelay:  config.RetryMaxDelay,
+		breaker:        newBreaker(config.Breaker),
+		concurrency:    config.Concurrency,
 	}
 }
 
+// BreakerState returns the current state of the client's circuit breaker
+func (c *Client) BreakerState() BreakerState {
+	return c.breaker.State()
+}
+
+// BreakerStatus returns the current state of the client's circuit breaker as text,
+// reported in the health of a fallback chain
+func (c *Client) BreakerStatus() string {
+	return c.BreakerState().String()
+}
+
 // GetStarships fetches and returns all starships from the SWAPI API
 // Returns domain.Starship objects instead of API responses
 func (c *Client) GetStarships(ctx context.Context) ([]domain.Starship, error) {
+	pages, err := c.fetchAllPages(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	var allStarships []domain.Starship
-	nextURL := fmt.Sprintf("%s/api/starships/", c.baseURL)
+	for _, response := range pages {
+		allStarships = append(allStarships, toDomainStarships(response.Results)...)
+	}
 
-	for nextURL != "" {
-		response, err := c.fetchStarshipsPage(ctx, nextURL)
-		if err != nil {
-			return nil, fmt.Errorf("fetch starships page: %w", err)
-		}
+	return allStarships, nil
+}
 
-		for _, apiShip := range response.Results {
-			ship, err := apiToDomainStarship(apiShip)
-			if err != nil {
-				if errors.Is(err, ErrSkipShip) {
-					continue // Skip ship silently
-				}
-				fmt.Printf("Warning: could not process ship %s: %v\n", apiShip.Name, err)
-				continue
-			}
-			allStarships = append(allStarships, ship)
-		}
+// FetchSnapshot crawls every page of the SWAPI starships listing and merges them
+// into a single response, in the shape expected by SnapshotClient
+func (c *Client) FetchSnapshot(ctx context.Context) (*StarshipsResponse, error) {
+	pages, err := c.fetchAllPages(ctx)
+	if err != nil {
+		return nil, err
+	}
 
-		nextURL = response.Next
+	snapshot := &StarshipsResponse{}
+	for _, response := range pages {
+		snapshot.Results = append(snapshot.Results, response.Results...)
 	}
+	snapshot.Count = len(snapshot.Results)
 
-	return allStarships, nil
+	return snapshot, nil
 }
 
-// apiToDomainStarship converts an APIStarship to a domain.Starship
-func apiToDomainStarship(apiShip APIStarship) (domain.Starship, error) {
-	// Skip ships with non-numeric MGLT values
-	if apiShip.MGLT == "unknown" || apiShip.MGLT == "n/a" {
-		return domain.Starship{}, ErrSkipShip
+// toDomainStarships converts API starships to domain starships, dropping the ones
+// that can't be used for calculations
+func toDomainStarships(apiShips []APIStarship) []domain.Starship {
+	starships := make([]domain.Starship, 0, len(apiShips))
+	for _, apiShip := range apiShips {
+		starships = append(starships, apiToDomainStarship(apiShip))
 	}
+	return starships
+}
 
+// apiToDomainStarship converts an APIStarship to a domain.Starship.
+// Ships with a non-numeric MGLT (e.g. "unknown" or "n/a") are kept and flagged,
+// so the calculator can report them as skipped.
+func apiToDomainStarship(apiShip APIStarship) domain.Starship {
 	mglt, err := strconv.Atoi(apiShip.MGLT)
-	if err != nil {
-		return domain.Starship{}, fmt.Errorf("parse MGLT '%s': %w", apiShip.MGLT, err)
-	}
-
 	return domain.Starship{
+		ID:          starshipID(apiShip.URL),
 		Name:        apiShip.Name,
 		MGLT:        mglt,
+		UnknownMGLT: err != nil,
 		Consumables: apiShip.Consumables,
-	}, nil
+
+		Model:                apiShip.Model,
+		Manufacturers:        parser.ParseManufacturers(apiShip.Manufacturer),
+		Class:                apiShip.StarshipClass,
+		Cost:                 parser.QuantityOrUnknown(apiShip.CostInCredits),
+		Length:               parser.MeasureOrUnknown(apiShip.Length),
+		MaxAtmospheringSpeed: parser.MeasureOrUnknown(apiShip.MaxAtmospheringSpeed),
+		HyperdriveRating:     parser.MeasureOrUnknown(apiShip.HyperdriveRating),
+		Crew:                 parser.QuantityOrUnknown(apiShip.Crew),
+		Passengers:           parser.QuantityOrUnknown(apiShip.Passengers),
+		CargoCapacity:        parser.QuantityOrUnknown(apiShip.CargoCapacity),
+	}
+}
+
+// starshipID extracts the SWAPI id from a starship URL such as
+// "https://swapi.dev/api/starships/12/"
+func starshipID(url string) string {
+	url = strings.TrimSuffix(url, "/")
+	return url[strings.LastIndex(url, "/")+1:]
 }
 
-// fetchStarshipsPage fetches a single page of starship data from the API
+// fetchStarshipsPage fetches a single page of starship data from the API,
+// failing fast while the circuit breaker is open
 func (c *Client) fetchStarshipsPage(ctx context.Context, url string) (*StarshipsResponse, error) {
+	if err := c.breaker.allow(); err != nil {
+		return nil, err
+	}
+
+	page, err := c.fetchStarshipsPageWithRetry(ctx, url)
+	switch {
+	case err == nil:
+		c.breaker.record(false)
+	case ctx.Err() != nil:
+		c.breaker.release()
+	default:
+		c.breaker.record(isUpstreamFailure(err))
+	}
+	return page, err
+}
+
+// fetchStarshipsPageWithRetry retries transient page fetch failures with exponential backoff
+func (c *Client) fetchStarshipsPageWithRetry(ctx context.Context, url string) (*StarshipsResponse, error) {
+	for attempt := 0; ; attempt++ {
+		page, err := c.doFetchStarshipsPage(ctx, url)
+		if err == nil {
+			return page, nil
+		}
+		if attempt >= c.maxRetries || ctx.Err() != nil || !isRetryable(err) {
+			return nil, err
+		}
+
+		if sleepErr := sleepContext(ctx, c.retryDelay(attempt, err)); sleepErr != nil {
+			return nil, fmt.Errorf("%w (giving up retries: %v)", err, sleepErr)
+		}
+	}
+}
+
+// doFetchStarshipsPage performs a single attempt at fetching a page of starship data
+func (c *Client) doFetchStarshipsPage(ctx context.Context, url string) (*StarshipsResponse, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("create request: %w", err)
 	}
+	c.pages.setConditionalHeaders(req)
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
@@ -102,14 +198,25 @@ func (c *Client) fetchStarshipsPage(ctx context.Context, url string) (*Starships
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusNotModified {
+		if page, ok := c.pages.notModified(req.URL.String()); ok {
+			return page, nil
+		}
+	}
+
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		statusErr := &StatusError{StatusCode: resp.StatusCode}
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable {
+			statusErr.RetryAfter = parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+		}
+		return nil, statusErr
 	}
 
 	var starshipsResp StarshipsResponse
 	if err := json.NewDecoder(resp.Body).Decode(&starshipsResp); err != nil {
 		return nil, fmt.Errorf("decode response: %w", err)
 	}
+	c.pages.save(req.URL.String(), resp.Header, &starshipsResp)
 
 	return &starshipsResp, nil
 }
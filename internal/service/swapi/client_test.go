@@ -2,10 +2,10 @@ package swapi
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"testing"
 
 	"github.com/pvdevs/get-starships-stops/internal/domain"
@@ -137,14 +137,13 @@ func TestClient_handlePagination(t *testing.T) {
 // TestAPIToDomainStarship verifies the conversion of APIStarship to domain.Starship.
 // It tests cases including:
 // - Valid starship data
-// - Skipping ships with invalid MGLT values
-// - Handling invalid MGLT formats
+// - Flagging ships with unknown or invalid MGLT values
+// - Parsing the class and counts, leaving unusable counts unknown
 func TestAPIToDomainStarship(t *testing.T) {
 	tests := []struct {
-		name    string          // Test case description
-		input   APIStarship     // Input APIStarship data
-		want    domain.Starship // Expected domain.Starship result
-		wantErr error           // Expected error (if any)
+		name  string          // Test case description
+		input APIStarship     // Input APIStarship data
+		want  domain.Starship // Expected domain.Starship result
 	}{
 		{
 			name: "valid starship",
@@ -158,7 +157,53 @@ func TestAPIToDomainStarship(t *testing.T) {
 				MGLT:        100,
 				Consumables: "1 week",
 			},
-			wantErr: nil,
+		},
+		{
+			name: "id from starship URL",
+			input: APIStarship{
+				Name:        "X-wing",
+				MGLT:        "100",
+				Consumables: "1 week",
+				URL:         "https://swapi.dev/api/starships/12/",
+			},
+			want: domain.Starship{
+				ID:          "12",
+				Name:        "X-wing",
+				MGLT:        100,
+				Consumables: "1 week",
+			},
+		},
+		{
+			name: "all attributes",
+			input: APIStarship{
+				Name:                 "Millennium Falcon",
+				Model:                "YT-1300 light freighter",
+				Manufacturer:         "Corellian Engineering Corporation",
+				CostInCredits:        "100000",
+				Length:               "34.37",
+				MaxAtmospheringSpeed: "1050",
+				Crew:                 "4",
+				Passengers:           "6",
+				CargoCapacity:        "unknown",
+				Consumables:          "2 months",
+				HyperdriveRating:     "0.5",
+				MGLT:                 "75",
+				StarshipClass:        "Light freighter",
+			},
+			want: domain.Starship{
+				Name:                 "Millennium Falcon",
+				MGLT:                 75,
+				Consumables:          "2 months",
+				Model:                "YT-1300 light freighter",
+				Manufacturers:        []string{"Corellian Engineering Corporation"},
+				Class:                "Light freighter",
+				Cost:                 domain.Quantity{Min: 100000, Max: 100000, Known: true},
+				Length:               domain.Measure{Value: 34.37, Known: true},
+				MaxAtmospheringSpeed: domain.Measure{Value: 1050, Known: true},
+				HyperdriveRating:     domain.Measure{Value: 0.5, Known: true},
+				Crew:                 domain.Quantity{Min: 4, Max: 4, Known: true},
+				Passengers:           domain.Quantity{Min: 6, Max: 6, Known: true},
+			},
 		},
 		{
 			name: "unknown MGLT",
@@ -167,7 +212,11 @@ func TestAPIToDomainStarship(t *testing.T) {
 				MGLT:        "unknown",
 				Consumables: "1 month",
 			},
-			wantErr: ErrSkipShip,
+			want: domain.Starship{
+				Name:        "Unknown Ship",
+				UnknownMGLT: true,
+				Consumables: "1 month",
+			},
 		},
 		{
 			name: "n/a MGLT",
@@ -176,7 +225,11 @@ func TestAPIToDomainStarship(t *testing.T) {
 				MGLT:        "n/a",
 				Consumables: "1 month",
 			},
-			wantErr: ErrSkipShip,
+			want: domain.Starship{
+				Name:        "NA Ship",
+				UnknownMGLT: true,
+				Consumables: "1 month",
+			},
 		},
 		{
 			name: "invalid MGLT number",
@@ -185,31 +238,18 @@ func TestAPIToDomainStarship(t *testing.T) {
 				MGLT:        "not a number",
 				Consumables: "1 month",
 			},
-			wantErr: fmt.Errorf("invalid MGLT format"),
+			want: domain.Starship{
+				Name:        "Broken Ship",
+				UnknownMGLT: true,
+				Consumables: "1 month",
+			},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := apiToDomainStarship(tt.input)
-
-			if tt.wantErr != nil {
-				if err == nil {
-					t.Error("apiToDomainStarship() expected error, got nil")
-					return
-				}
-				if tt.wantErr == ErrSkipShip && !errors.Is(err, ErrSkipShip) {
-					t.Errorf("expected ErrSkipShip, got %v", err)
-				}
-				return
-			}
-
-			if err != nil {
-				t.Errorf("apiToDomainStarship() unexpected error: %v", err)
-				return
-			}
-
-			if got != tt.want {
+			got := apiToDomainStarship(tt.input)
+			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("apiToDomainStarship() = %v, want %v", got, tt.want)
 			}
 		})
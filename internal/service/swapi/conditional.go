@@ -0,0 +1,66 @@
+package swapi
+
+import (
+	"net/http"
+	"sync"
+)
+
+// validatedPage is a previously fetched page along with the validators SWAPI sent for it
+type validatedPage struct {
+	etag         string
+	lastModified string
+	page         *StarshipsResponse
+}
+
+// pageStore keeps the last fetched version of every page URL, so that pages can be
+// requested conditionally and reused when SWAPI answers 304 Not Modified
+type pageStore struct {
+	mu    sync.Mutex
+	pages map[string]validatedPage
+}
+
+// setConditionalHeaders adds If-None-Match and If-Modified-Since to the request
+// when a validated version of its URL is stored
+func (s *pageStore) setConditionalHeaders(req *http.Request) {
+	s.mu.Lock()
+	stored, ok := s.pages[req.URL.String()]
+	s.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	if stored.etag != "" {
+		req.Header.Set("If-None-Match", stored.etag)
+	}
+	if stored.lastModified != "" {
+		req.Header.Set("If-Modified-Since", stored.lastModified)
+	}
+}
+
+// notModified returns the stored page for a URL SWAPI reported as unchanged
+func (s *pageStore) notModified(url string) (*StarshipsResponse, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stored, ok := s.pages[url]
+	return stored.page, ok
+}
+
+// save stores a freshly fetched page, if SWAPI sent validators for it
+func (s *pageStore) save(url string, header http.Header, page *StarshipsResponse) {
+	etag, lastModified := header.Get("ETag"), header.Get("Last-Modified")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if etag == "" && lastModified == "" {
+		delete(s.pages, url)
+		return
+	}
+	if s.pages == nil {
+		s.pages = make(map[string]validatedPage)
+	}
+	s.pages[url] = validatedPage{
+		etag:         etag,
+		lastModified: lastModified,
+		page:         page,
+	}
+}
@@ -0,0 +1,68 @@
+package swapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+// TestClient_conditionalRequests verifies that pages are revalidated with the
+// validators SWAPI sent, and reused when SWAPI answers 304 Not Modified.
+// It tests scenarios including:
+// - Revalidation through ETag
+// - Revalidation through Last-Modified
+// - No conditional headers when SWAPI sends no validators
+func TestClient_conditionalRequests(t *testing.T) {
+	const page = `{"count":1,"next":null,"previous":null,"results":[{"name":"X-wing","MGLT":"100","consumables":"1 week"}]}`
+	const lastModified = "Mon, 01 Jan 2024 12:00:00 GMT"
+
+	tests := []struct {
+		name         string // Test case description
+		etag         string // ETag sent by the server
+		lastModified string // Last-Modified sent by the server
+		wantNotMod   int32  // Expected number of 304 answers over two fetches
+	}{
+		{name: "etag", etag: `"v1"`, wantNotMod: 1},
+		{name: "last modified", lastModified: lastModified, wantNotMod: 1},
+		{name: "no validators", wantNotMod: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var notModified atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if (tt.etag != "" && r.Header.Get("If-None-Match") == tt.etag) ||
+					(tt.lastModified != "" && r.Header.Get("If-Modified-Since") == tt.lastModified) {
+					notModified.Add(1)
+					w.WriteHeader(http.StatusNotModified)
+					return
+				}
+				if tt.etag != "" {
+					w.Header().Set("ETag", tt.etag)
+				}
+				if tt.lastModified != "" {
+					w.Header().Set("Last-Modified", tt.lastModified)
+				}
+				_, _ = w.Write([]byte(page))
+			}))
+			defer server.Close()
+
+			client := NewClient(ClientConfig{BaseURL: server.URL})
+			for i := 0; i < 2; i++ {
+				starships, err := client.GetStarships(context.Background())
+				if err != nil {
+					t.Fatalf("fetch %d: GetStarships() error = %v", i, err)
+				}
+				if len(starships) != 1 || starships[0].Name != "X-wing" {
+					t.Fatalf("fetch %d: unexpected starships %+v", i, starships)
+				}
+			}
+
+			if got := notModified.Load(); got != tt.wantNotMod {
+				t.Errorf("expected %d not modified answers, got %d", tt.wantNotMod, got)
+			}
+		})
+	}
+}
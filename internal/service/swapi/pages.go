@@ -0,0 +1,151 @@
+package swapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"sync"
+)
+
+const (
+	defaultConcurrency = 4
+)
+
+// fetchAllPages fetches every page of the starships listing, in SWAPI's order.
+// The first page tells how many starships exist, so the remaining pages are
+// fetched concurrently instead of following the "next" links one by one.
+func (c *Client) fetchAllPages(ctx context.Context) ([]*StarshipsResponse, error) {
+	first, err := c.fetchStarshipsPage(ctx, fmt.Sprintf("%s/api/starships/", c.baseURL))
+	if err != nil {
+		return nil, fmt.Errorf("fetch starships page: %w", err)
+	}
+	pages := []*StarshipsResponse{first}
+	if first.Next == "" {
+		return pages, nil
+	}
+
+	urls, err := remainingPageURLs(first)
+	if err != nil {
+		// The page count can't be derived, fall back to following the links
+		return c.followPages(ctx, pages)
+	}
+
+	rest, err := c.fetchPagesConcurrently(ctx, urls)
+	if err != nil {
+		return nil, err
+	}
+	// Pages past the end of a listing that shrank since the count was reported are missing,
+	// the pages before them are the whole listing
+	if end := slices.Index(rest, nil); end >= 0 {
+		if slices.ContainsFunc(rest[end:], func(page *StarshipsResponse) bool { return page != nil }) {
+			return nil, fmt.Errorf("fetch starships page %s: %w", urls[end], &StatusError{StatusCode: http.StatusNotFound})
+		}
+		return append(pages, rest[:end]...), nil
+	}
+	pages = append(pages, rest...)
+
+	// Keep going if SWAPI grew since the count was reported
+	return c.followPages(ctx, pages)
+}
+
+// followPages sequentially fetches pages from the "next" link of the last page
+func (c *Client) followPages(ctx context.Context, pages []*StarshipsResponse) ([]*StarshipsResponse, error) {
+	for nextURL := pages[len(pages)-1].Next; nextURL != ""; nextURL = pages[len(pages)-1].Next {
+		response, err := c.fetchStarshipsPage(ctx, nextURL)
+		if err != nil {
+			return nil, fmt.Errorf("fetch starships page: %w", err)
+		}
+		pages = append(pages, response)
+	}
+	return pages, nil
+}
+
+// fetchPagesConcurrently fetches the given pages with a bounded number of workers.
+// Pages are returned in the order of urls, and the first failure cancels the other fetches.
+// Pages answering 404 are left nil, as derived URLs may point past the end of the listing.
+func (c *Client) fetchPagesConcurrently(ctx context.Context, urls []string) ([]*StarshipsResponse, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	pages := make([]*StarshipsResponse, len(urls))
+	indexes := make(chan int)
+
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+	for range min(c.concurrency, len(urls)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				response, err := c.fetchStarshipsPage(ctx, urls[i])
+				if isNotFound(err) {
+					continue
+				}
+				if err != nil {
+					errOnce.Do(func() {
+						firstErr = fmt.Errorf("fetch starships page: %w", err)
+						cancel()
+					})
+					continue
+				}
+				pages[i] = response
+			}
+		}()
+	}
+
+dispatch:
+	for i := range urls {
+		select {
+		case indexes <- i:
+		case <-ctx.Done():
+			break dispatch
+		}
+	}
+	close(indexes)
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("fetch starships page: %w", err)
+	}
+	return pages, nil
+}
+
+// isNotFound reports whether a page fetch failed because SWAPI has no such page
+func isNotFound(err error) bool {
+	var statusErr *StatusError
+	return errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNotFound
+}
+
+// remainingPageURLs builds the URLs of pages 2..N from the first page, using its
+// "next" link as a template and its count and page size to know N
+func remainingPageURLs(first *StarshipsResponse) ([]string, error) {
+	pageSize := len(first.Results)
+	if pageSize == 0 || first.Count <= pageSize {
+		return nil, fmt.Errorf("cannot derive page count from count %d and page size %d", first.Count, pageSize)
+	}
+
+	next, err := url.Parse(first.Next)
+	if err != nil {
+		return nil, fmt.Errorf("parse next URL: %w", err)
+	}
+
+	totalPages := (first.Count + pageSize - 1) / pageSize
+	urls := make([]string, 0, totalPages-1)
+	for page := 2; page <= totalPages; page++ {
+		query := next.Query()
+		query.Set("page", strconv.Itoa(page))
+		next.RawQuery = query.Encode()
+		urls = append(urls, next.String())
+	}
+	return urls, nil
+}
@@ -0,0 +1,161 @@
+package swapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newPagedServer serves `pages` pages of `perPage` starships each, named "Ship <n>".
+// Later pages answer faster so out-of-order completion is exercised, and failPage
+// (if non-zero) always answers with a 500.
+func newPagedServer(t *testing.T, pages, perPage, failPage int, inFlight, maxInFlight *atomic.Int32) *httptest.Server {
+	t.Helper()
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			seen := maxInFlight.Load()
+			if current <= seen || maxInFlight.CompareAndSwap(seen, current) {
+				break
+			}
+		}
+
+		page := 1
+		if p := r.URL.Query().Get("page"); p != "" {
+			page, _ = strconv.Atoi(p)
+		}
+		time.Sleep(time.Duration(pages-page) * 2 * time.Millisecond)
+
+		if page == failPage {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		next := "null"
+		if page < pages {
+			next = fmt.Sprintf(`"%s/api/starships/?page=%d"`, server.URL, page+1)
+		}
+		var results []string
+		for i := 0; i < perPage; i++ {
+			n := (page-1)*perPage + i + 1
+			results = append(results, fmt.Sprintf(`{"name":"Ship %d","MGLT":"10","consumables":"1 week"}`, n))
+		}
+		fmt.Fprintf(w, `{"count":%d,"next":%s,"previous":null,"results":[%s]}`,
+			pages*perPage, next, strings.Join(results, ","))
+	}))
+	return server
+}
+
+// TestClient_parallelPages verifies that the remaining pages are fetched concurrently.
+// It ensures that:
+// - All starships are returned in SWAPI's order
+// - No more than the configured number of pages are fetched at once
+// - A failing page makes the whole fetch fail
+func TestClient_parallelPages(t *testing.T) {
+	tests := []struct {
+		name        string // Test case description
+		pages       int    // Number of pages served
+		failPage    int    // Page answering with an error, 0 for none
+		concurrency int    // Client page concurrency
+		wantErr     bool   // Whether an error is expected
+	}{
+		{name: "many pages in order", pages: 9, concurrency: 3},
+		{name: "single page", pages: 1, concurrency: 3},
+		{name: "failing page", pages: 9, failPage: 5, concurrency: 3, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			const perPage = 4
+			var inFlight, maxInFlight atomic.Int32
+			server := newPagedServer(t, tt.pages, perPage, tt.failPage, &inFlight, &maxInFlight)
+			defer server.Close()
+
+			client := NewClient(ClientConfig{
+				BaseURL:     server.URL,
+				Concurrency: tt.concurrency,
+			})
+			starships, err := client.GetStarships(context.Background())
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetStarships() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := maxInFlight.Load(); int(got) > tt.concurrency {
+				t.Errorf("expected at most %d concurrent requests, got %d", tt.concurrency, got)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if len(starships) != tt.pages*perPage {
+				t.Fatalf("expected %d starships, got %d", tt.pages*perPage, len(starships))
+			}
+			for i, ship := range starships {
+				if want := fmt.Sprintf("Ship %d", i+1); ship.Name != want {
+					t.Errorf("starship %d: expected %s, got %s", i, want, ship.Name)
+				}
+			}
+		})
+	}
+}
+
+// TestClient_shrunkListing verifies that pages derived from a count reported before SWAPI
+// shrank end the listing when they answer 404, while a page missing before the end of
+// the listing still fails the fetch.
+func TestClient_shrunkListing(t *testing.T) {
+	tests := []struct {
+		name      string // Test case description
+		lastPage  int    // Last page served, the first one still reports 3 pages
+		missing   int    // Page answering 404 although the previous one links to it, 0 for none
+		wantErr   bool   // Whether an error is expected
+		wantShips int    // Expected number of starships
+	}{
+		{name: "listing shrank by a page", lastPage: 2, wantShips: 8},
+		{name: "listing shrank to the first page", lastPage: 1, wantShips: 4},
+		{name: "page missing before the end", lastPage: 3, missing: 2, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			const perPage = 4
+			var server *httptest.Server
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				page := 1
+				if p := r.URL.Query().Get("page"); p != "" {
+					page, _ = strconv.Atoi(p)
+				}
+				if page > tt.lastPage || page == tt.missing {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+
+				next := "null"
+				if page < tt.lastPage || page == 1 {
+					next = fmt.Sprintf(`"%s/api/starships/?page=%d"`, server.URL, page+1)
+				}
+				var results []string
+				for i := 0; i < perPage; i++ {
+					results = append(results, fmt.Sprintf(`{"name":"Ship %d","MGLT":"10","consumables":"1 week"}`, (page-1)*perPage+i+1))
+				}
+				fmt.Fprintf(w, `{"count":%d,"next":%s,"previous":null,"results":[%s]}`, 3*perPage, next, strings.Join(results, ","))
+			}))
+			defer server.Close()
+
+			starships, err := NewClient(ClientConfig{BaseURL: server.URL}).GetStarships(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetStarships() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(starships) != tt.wantShips {
+				t.Errorf("expected %d starships, got %d", tt.wantShips, len(starships))
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package swapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+const (
+	defaultRetryBaseDelay = 200 * time.Millisecond
+	defaultRetryMaxDelay  = 5 * time.Second
+)
+
+// StatusError is returned when SWAPI answers with a non-200 status code
+type StatusError struct {
+	StatusCode int           // HTTP status code returned by SWAPI
+	RetryAfter time.Duration // Delay requested through the Retry-After header, if any
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("unexpected status code: %d", e.StatusCode)
+}
+
+// isRetryable reports whether a failed page fetch is worth attempting again.
+// Server errors, rate limiting, timeouts and dropped connections are retried;
+// client errors and malformed payloads are not.
+func isRetryable(err error) bool {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode >= http.StatusInternalServerError ||
+			statusErr.StatusCode == http.StatusTooManyRequests
+	}
+
+	if errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, syscall.ECONNRESET) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// retryDelay returns how long to wait before the given retry attempt (starting at 0).
+// The exponential backoff is jittered to spread out retries from concurrent callers,
+// and a longer Retry-After requested by the server wins. Both are capped at the maximum
+// delay, so that fetches without a deadline never stall on a server asking for hours.
+func (c *Client) retryDelay(attempt int, err error) time.Duration {
+	backoff := c.retryBaseDelay << attempt
+	if backoff <= 0 || backoff > c.retryMaxDelay {
+		backoff = c.retryMaxDelay
+	}
+	delay := backoff/2 + rand.N(backoff/2+1)
+
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) && statusErr.RetryAfter > delay {
+		delay = min(statusErr.RetryAfter, c.retryMaxDelay)
+	}
+	return delay
+}
+
+// parseRetryAfter reads a Retry-After header given either in seconds or as an HTTP date.
+// Returns zero when the header is missing or cannot be parsed.
+func parseRetryAfter(header string, now time.Time) time.Duration {
+	if header == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(header); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(header); err == nil && date.After(now) {
+		return date.Sub(now)
+	}
+	return 0
+}
+
+// sleepContext waits for the given delay, returning early if ctx is done or
+// its deadline would pass before the delay elapses.
+func sleepContext(ctx context.Context, delay time.Duration) error {
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+		return context.DeadlineExceeded
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,181 @@
+package swapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestClient_retries verifies how page fetches are retried.
+// It tests scenarios including:
+// - Transient server errors recovered by a retry
+// - Rate limiting recovered by a retry
+// - Client errors that are never retried
+// - Giving up once the retries are exhausted
+func TestClient_retries(t *testing.T) {
+	const page = `{"count":1,"next":null,"previous":null,"results":[{"name":"X-wing","MGLT":"100","consumables":"1 week"}]}`
+
+	tests := []struct {
+		name         string // Test case description
+		failures     int    // Number of requests failing before the server recovers
+		failStatus   int    // Status code returned by failing requests
+		maxRetries   int    // Retries configured on the client
+		wantErr      bool   // Whether an error is expected
+		wantRequests int32  // Expected number of requests received by the server
+	}{
+		{
+			name:         "recovers from server error",
+			failures:     2,
+			failStatus:   http.StatusBadGateway,
+			maxRetries:   3,
+			wantRequests: 3,
+		},
+		{
+			name:         "recovers from rate limiting",
+			failures:     1,
+			failStatus:   http.StatusTooManyRequests,
+			maxRetries:   3,
+			wantRequests: 2,
+		},
+		{
+			name:         "does not retry client error",
+			failures:     1,
+			failStatus:   http.StatusNotFound,
+			maxRetries:   3,
+			wantErr:      true,
+			wantRequests: 1,
+		},
+		{
+			name:         "gives up after max retries",
+			failures:     5,
+			failStatus:   http.StatusInternalServerError,
+			maxRetries:   2,
+			wantErr:      true,
+			wantRequests: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if int(requests.Add(1)) <= tt.failures {
+					w.WriteHeader(tt.failStatus)
+					return
+				}
+				_, _ = w.Write([]byte(page))
+			}))
+			defer server.Close()
+
+			client := NewClient(ClientConfig{
+				BaseURL:        server.URL,
+				MaxRetries:     tt.maxRetries,
+				RetryBaseDelay: time.Millisecond,
+				RetryMaxDelay:  5 * time.Millisecond,
+			})
+			_, err := client.GetStarships(context.Background())
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetStarships() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := requests.Load(); got != tt.wantRequests {
+				t.Errorf("expected %d requests, got %d", tt.wantRequests, got)
+			}
+		})
+	}
+}
+
+// TestClient_retryRespectsDeadline verifies that a Retry-After longer than the caller's
+// deadline ends the fetch immediately instead of sleeping past it.
+func TestClient_retryRespectsDeadline(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewClient(ClientConfig{
+		BaseURL:        server.URL,
+		MaxRetries:     3,
+		RetryBaseDelay: time.Millisecond,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.GetStarships(ctx)
+
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("expected 503 StatusError, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 250*time.Millisecond {
+		t.Errorf("expected fetch to give up before the deadline, took %s", elapsed)
+	}
+}
+
+// TestClient_retryAfterCapped verifies that a Retry-After longer than the maximum delay
+// is cut down to it, so that a fetch without a deadline doesn't stall.
+func TestClient_retryAfterCapped(t *testing.T) {
+	const page = `{"count":1,"next":null,"previous":null,"results":[{"name":"X-wing","MGLT":"100","consumables":"1 week"}]}`
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			w.Header().Set("Retry-After", "86400")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write([]byte(page))
+	}))
+	defer server.Close()
+
+	client := NewClient(ClientConfig{
+		BaseURL:        server.URL,
+		MaxRetries:     1,
+		RetryBaseDelay: time.Millisecond,
+		RetryMaxDelay:  20 * time.Millisecond,
+	})
+
+	start := time.Now()
+	if _, err := client.GetStarships(context.Background()); err != nil {
+		t.Fatalf("GetStarships() error = %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected the Retry-After to be capped at the maximum delay, took %s", elapsed)
+	}
+	if delay := client.retryDelay(0, &StatusError{StatusCode: http.StatusTooManyRequests, RetryAfter: 24 * time.Hour}); delay != 20*time.Millisecond {
+		t.Errorf("expected a delay of 20ms, got %s", delay)
+	}
+}
+
+// TestParseRetryAfter verifies parsing of the Retry-After header in both of its formats.
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string        // Test case description
+		header string        // Retry-After header value
+		want   time.Duration // Expected delay
+	}{
+		{name: "seconds", header: "120", want: 2 * time.Minute},
+		{name: "http date", header: "Mon, 01 Jan 2024 12:00:30 GMT", want: 30 * time.Second},
+		{name: "date in the past", header: "Mon, 01 Jan 2024 11:00:00 GMT", want: 0},
+		{name: "negative seconds", header: "-5", want: 0},
+		{name: "missing header", header: "", want: 0},
+		{name: "garbage", header: "soon", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseRetryAfter(tt.header, now); got != tt.want {
+				t.Errorf("parseRetryAfter(%q) = %s, want %s", tt.header, got, tt.want)
+			}
+		})
+	}
+}
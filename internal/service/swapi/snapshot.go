@@ -0,0 +1,59 @@
+package swapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/pvdevs/get-starships-stops/internal/domain"
+)
+
+// SnapshotClient serves starships from a JSON snapshot on disk instead of the SWAPI API.
+// The snapshot uses the StarshipsResponse shape, with every starship in a single page.
+type SnapshotClient struct {
+	starships []domain.Starship
+}
+
+// NewSnapshotClient loads the snapshot at path and returns a client serving its starships
+func NewSnapshotClient(path string) (*SnapshotClient, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open snapshot: %w", err)
+	}
+	defer file.Close()
+
+	snapshot, err := ReadSnapshot(file)
+	if err != nil {
+		return nil, fmt.Errorf("read snapshot %s: %w", path, err)
+	}
+
+	return &SnapshotClient{
+		starships: toDomainStarships(snapshot.Results),
+	}, nil
+}
+
+// GetStarships returns the starships loaded from the snapshot
+func (c *SnapshotClient) GetStarships(ctx context.Context) ([]domain.Starship, error) {
+	return c.starships, nil
+}
+
+// ReadSnapshot decodes a snapshot previously written by WriteSnapshot
+func ReadSnapshot(r io.Reader) (*StarshipsResponse, error) {
+	var snapshot StarshipsResponse
+	if err := json.NewDecoder(r).Decode(&snapshot); err != nil {
+		return nil, fmt.Errorf("decode snapshot: %w", err)
+	}
+	return &snapshot, nil
+}
+
+// WriteSnapshot encodes a snapshot as indented JSON, so it can be reviewed and diffed
+func WriteSnapshot(w io.Writer, snapshot *StarshipsResponse) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(snapshot); err != nil {
+		return fmt.Errorf("encode snapshot: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package swapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestSnapshotClient verifies loading starships from a snapshot file.
+// It tests scenarios including:
+// - A valid snapshot, keeping ships with unknown MGLT like the live client does
+// - A missing snapshot file
+// - A snapshot with invalid JSON
+func TestSnapshotClient(t *testing.T) {
+	tests := []struct {
+		name      string // Test case description
+		content   string // Snapshot file content, empty for a missing file
+		wantErr   bool   // Whether an error is expected
+		wantNames []string
+	}{
+		{
+			name:      "valid snapshot",
+			content:   `{"count":2,"next":"","previous":"","results":[{"name":"X-wing","MGLT":"100","consumables":"1 week"},{"name":"Death Star","MGLT":"unknown","consumables":"3 years"}]}`,
+			wantNames: []string{"X-wing", "Death Star"},
+		},
+		{
+			name:    "missing snapshot",
+			wantErr: true,
+		},
+		{
+			name:    "invalid json",
+			content: `{invalid json}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "starships.json")
+			if tt.content != "" {
+				if err := os.WriteFile(path, []byte(tt.content), 0o644); err != nil {
+					t.Fatalf("write snapshot: %v", err)
+				}
+			}
+
+			client, err := NewSnapshotClient(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewSnapshotClient() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			starships, err := client.GetStarships(context.Background())
+			if err != nil {
+				t.Fatalf("GetStarships() error = %v", err)
+			}
+			if len(starships) != len(tt.wantNames) {
+				t.Fatalf("expected %d starships, got %d", len(tt.wantNames), len(starships))
+			}
+			for i, ship := range starships {
+				if ship.Name != tt.wantNames[i] {
+					t.Errorf("expected starship %s, got %s", tt.wantNames[i], ship.Name)
+				}
+			}
+		})
+	}
+}
+
+// TestClient_FetchSnapshot verifies that a recorded crawl can be written and served
+// back by SnapshotClient with the same starships.
+func TestClient_FetchSnapshot(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			fmt.Fprint(w, `{"count":2,"next":null,"previous":null,"results":[{"name":"Y-wing","MGLT":"80","consumables":"1 week"}]}`)
+			return
+		}
+		fmt.Fprintf(w, `{"count":2,"next":"%s/api/starships/?page=2","previous":null,"results":[{"name":"X-wing","MGLT":"100","consumables":"1 week"}]}`, server.URL)
+	}))
+	defer server.Close()
+
+	snapshot, err := NewClient(ClientConfig{BaseURL: server.URL}).FetchSnapshot(context.Background())
+	if err != nil {
+		t.Fatalf("FetchSnapshot() error = %v", err)
+	}
+	if snapshot.Count != 2 || len(snapshot.Results) != 2 {
+		t.Fatalf("expected 2 starships in snapshot, got count %d with %d results", snapshot.Count, len(snapshot.Results))
+	}
+
+	path := filepath.Join(t.TempDir(), "starships.json")
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("create snapshot: %v", err)
+	}
+	if err := WriteSnapshot(file, snapshot); err != nil {
+		t.Fatalf("WriteSnapshot() error = %v", err)
+	}
+	file.Close()
+
+	client, err := NewSnapshotClient(path)
+	if err != nil {
+		t.Fatalf("NewSnapshotClient() error = %v", err)
+	}
+	starships, _ := client.GetStarships(context.Background())
+	if len(starships) != 2 || starships[0].Name != "X-wing" || starships[1].Name != "Y-wing" {
+		t.Errorf("unexpected starships from snapshot: %+v", starships)
+	}
+}
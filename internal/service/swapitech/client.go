@@ -0,0 +1,113 @@
+package swapitech
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/pvdevs/get-starships-stops/internal/domain"
+	"github.com/pvdevs/get-starships-stops/internal/parser"
+)
+
+const (
+	pageLimit = 100 // Starships requested per page, swapi.tech pages hold 10 by default
+)
+
+// Client handles all communication with the swapi.tech API
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+type ClientConfig struct {
+	BaseURL string
+	Timeout time.Duration
+}
+
+// NewClient creates a new swapi.tech client instance
+func NewClient(config ClientConfig) *Client {
+	if config.Timeout == 0 {
+		config.Timeout = 10 * time.Second // default timeout
+	}
+	return &Client{
+		baseURL: config.BaseURL,
+		httpClient: &http.Client{
+			Timeout: config.Timeout,
+		},
+	}
+}
+
+// GetStarships fetches and returns all starships from the swapi.tech API.
+// The listing is requested expanded, so every page carries full starship properties.
+func (c *Client) GetStarships(ctx context.Context) ([]domain.Starship, error) {
+	var allStarships []domain.Starship
+	nextURL := fmt.Sprintf("%s/api/starships?page=1&limit=%d&expanded=true", c.baseURL, pageLimit)
+
+	for nextURL != "" {
+		response, err := c.fetchStarshipsPage(ctx, nextURL)
+		if err != nil {
+			return nil, fmt.Errorf("fetch starships page: %w", err)
+		}
+
+		for _, result := range response.Results {
+			allStarships = append(allStarships, apiToDomainStarship(result))
+		}
+
+		nextURL = response.Next
+	}
+
+	return allStarships, nil
+}
+
+// apiToDomainStarship converts a swapi.tech StarshipResult to a domain.Starship,
+// flagging ships with a non-numeric MGLT (e.g. "unknown" or "n/a")
+func apiToDomainStarship(result StarshipResult) domain.Starship {
+	props := result.Properties
+	mglt, err := strconv.Atoi(props.MGLT)
+	return domain.Starship{
+		ID:          result.UID,
+		Name:        props.Name,
+		MGLT:        mglt,
+		UnknownMGLT: err != nil,
+		Consumables: props.Consumables,
+
+		Model:                props.Model,
+		Manufacturers:        parser.ParseManufacturers(props.Manufacturer),
+		Class:                props.StarshipClass,
+		Cost:                 parser.QuantityOrUnknown(props.CostInCredits),
+		Length:               parser.MeasureOrUnknown(props.Length),
+		MaxAtmospheringSpeed: parser.MeasureOrUnknown(props.MaxAtmospheringSpeed),
+		HyperdriveRating:     parser.MeasureOrUnknown(props.HyperdriveRating),
+		Crew:                 parser.QuantityOrUnknown(props.Crew),
+		Passengers:           parser.QuantityOrUnknown(props.Passengers),
+		CargoCapacity:        parser.QuantityOrUnknown(props.CargoCapacity),
+	}
+}
+
+// fetchStarshipsPage fetches a single page of starship data from the API
+func (c *Client) fetchStarshipsPage(ctx context.Context, url string) (*StarshipsResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("do request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	var starshipsResp StarshipsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&starshipsResp); err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+
+	return &starshipsResp, nil
+}
@@ -0,0 +1,86 @@
+package swapitech
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestClient_GetStarships verifies fetching starships from the swapi.tech API.
+// It tests scenarios including:
+// - Following the "next" link across pages
+// - Reading starships from the nested "properties" payload
+// - Keeping ships with unknown MGLT, flagged
+// - Server errors and invalid JSON responses
+func TestClient_GetStarships(t *testing.T) {
+	tests := []struct {
+		name      string            // Test case description
+		pages     map[string]string // Mocked response per page query value
+		status    int               // Mocked HTTP status code
+		wantErr   bool              // Whether an error is expected
+		wantNames []string          // Expected starship names, in order
+	}{
+		{
+			name: "paginated expanded response",
+			pages: map[string]string{
+				"1": `{"message":"ok","total_records":3,"total_pages":2,"previous":null,"next":"%s/api/starships?page=2&limit=100&expanded=true","results":[
+					{"uid":"12","properties":{"name":"X-wing","MGLT":"100","consumables":"1 week"}},
+					{"uid":"9","properties":{"name":"Death Star","MGLT":"unknown","consumables":"3 years"}}]}`,
+				"2": `{"message":"ok","total_records":3,"total_pages":2,"previous":null,"next":null,"results":[
+					{"uid":"11","properties":{"name":"Y-wing","MGLT":"80","consumables":"1 week"}}]}`,
+			},
+			status:    http.StatusOK,
+			wantNames: []string{"X-wing", "Death Star", "Y-wing"},
+		},
+		{
+			name:    "server error",
+			pages:   map[string]string{"1": `{"message":"error"}`},
+			status:  http.StatusInternalServerError,
+			wantErr: true,
+		},
+		{
+			name:    "invalid json response",
+			pages:   map[string]string{"1": `{invalid json}`},
+			status:  http.StatusOK,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var server *httptest.Server
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Query().Get("expanded") != "true" {
+					t.Errorf("expected expanded listing, got query %s", r.URL.RawQuery)
+				}
+				w.WriteHeader(tt.status)
+				body := tt.pages[r.URL.Query().Get("page")]
+				if tt.status == http.StatusOK && r.URL.Query().Get("page") == "1" && tt.wantNames != nil {
+					body = fmt.Sprintf(body, server.URL)
+				}
+				_, _ = w.Write([]byte(body))
+			}))
+			defer server.Close()
+
+			client := NewClient(ClientConfig{BaseURL: server.URL})
+			starships, err := client.GetStarships(context.Background())
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetStarships() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(starships) != len(tt.wantNames) {
+				t.Fatalf("expected %d starships, got %d", len(tt.wantNames), len(starships))
+			}
+			for i, ship := range starships {
+				if ship.Name != tt.wantNames[i] {
+					t.Errorf("expected starship %s, got %s", tt.wantNames[i], ship.Name)
+				}
+				if ship.UnknownMGLT != (ship.Name == "Death Star") {
+					t.Errorf("unexpected UnknownMGLT %v for starship %s", ship.UnknownMGLT, ship.Name)
+				}
+			}
+		})
+	}
+}
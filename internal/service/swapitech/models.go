@@ -0,0 +1,41 @@
+package swapitech
+
+// APIStarship represents the starship properties returned by swapi.tech
+type APIStarship struct {
+	Name                 string   `json:"name"`
+	Model                string   `json:"model"`
+	Manufacturer         string   `json:"manufacturer"`
+	CostInCredits        string   `json:"cost_in_credits"`
+	Length               string   `json:"length"`
+	MaxAtmospheringSpeed string   `json:"max_atmosphering_speed"`
+	Crew                 string   `json:"crew"`
+	Passengers           string   `json:"passengers"`
+	CargoCapacity        string   `json:"cargo_capacity"`
+	Consumables          string   `json:"consumables"`
+	HyperdriveRating     string   `json:"hyperdrive_rating"`
+	MGLT                 string   `json:"MGLT"`
+	StarshipClass        string   `json:"starship_class"`
+	Pilots               []string `json:"pilots"`
+	Films                []string `json:"films"`
+	Created              string   `json:"created"`
+	Edited               string   `json:"edited"`
+	URL                  string   `json:"url"`
+}
+
+// StarshipResult is a single entry of an expanded swapi.tech listing,
+// wrapping the starship properties with its identifiers
+type StarshipResult struct {
+	UID         string      `json:"uid"`
+	Description string      `json:"description"`
+	Properties  APIStarship `json:"properties"`
+}
+
+// StarshipsResponse represents a page of the expanded swapi.tech starships listing.
+type StarshipsResponse struct {
+	Message      string           `json:"message"`
+	TotalRecords int              `json:"total_records"`
+	TotalPages   int              `json:"total_pages"`
+	Previous     string           `json:"previous"`
+	Next         string           `json:"next"`
+	Results      []StarshipResult `json:"results"`
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/pvdevs/get-starships-stops/internal/domain"
+)
+
+// Travel describes how long a starship takes to cover a distance
+type Travel struct {
+	Hours      int64 // Hours spent moving, distance / MGLT rounded up to a whole hour
+	Legs       int64 // Stretches travelled between stops, stops + 1
+	LegHours   int64 // Hours of a full leg, the last leg may be shorter
+	DwellHours int64 // Hours spent resupplying, stops × dwell per stop
+	TotalHours int64 // Hours + DwellHours
+}
+
+// TravelForShip computes how long a starship takes to cover a distance with the number of
+// stops counted by strategy, spending dwellHours resupplying at every stop. A nil strategy
+// is the exact rule of StopsForShip. Legs last the autonomy of the starship, or the leg
+// hours of a LegStrategy, shortened when needed so that the trip takes exactly stops + 1 legs.
+// Returns ErrOverflow when the total duration doesn't fit in an int64.
+func TravelForShip(ship domain.Starship, distance, stops, dwellHours int64, strategy StopStrategy) (Travel, error) {
+	legHours, _, err := shipRange(ship)
+	if err != nil && !errors.Is(err, ErrOverflow) {
+		return Travel{}, err
+	}
+	if legs, ok := strategy.(LegStrategy); ok {
+		if legHours, err = legs.LegHours(ship); err != nil {
+			return Travel{}, err
+		}
+	}
+
+	mglt := int64(ship.MGLT)
+	hours := distance / mglt
+	if distance%mglt != 0 {
+		hours++
+	}
+
+	dwell, err := mulInt64(stops, dwellHours)
+	if err != nil || hours > math.MaxInt64-dwell {
+		return Travel{}, fmt.Errorf("travel of %s: %w", ship.Name, ErrOverflow)
+	}
+
+	return Travel{
+		Hours:      hours,
+		Legs:       stops + 1,
+		LegHours:   fitLegHours(hours, stops+1, legHours),
+		DwellHours: dwell,
+		TotalHours: hours + dwell,
+	}, nil
+}
+
+// fitLegHours bounds the hours of a full leg so that legs legs cover the trip and the last
+// one isn't empty, as when a strategy stops before the range runs out. Trips shorter than
+// one hour per leg get legs of one hour.
+func fitLegHours(hours, legs, legHours int64) int64 {
+	if legs <= 1 {
+		return hours
+	}
+	shortest := hours / legs
+	if hours%legs != 0 {
+		shortest++
+	}
+	longest := (hours - 1) / (legs - 1)
+	return max(min(legHours, longest), shortest)
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/pvdevs/get-starships-stops/internal/domain"
+)
+
+// TestTravelForShip verifies the voyage duration of a starship.
+// It tests scenarios including:
+// - Trips shorter than a leg, and trips with several legs
+// - Travel hours rounded up to a whole hour
+// - Resupply dwell time added at every stop
+// - Legs of the margin strategy, and legs fitted to the stops of the ceiling strategy
+// - Durations that overflow an int64
+func TestTravelForShip(t *testing.T) {
+	xwing := domain.Starship{Name: "X-wing", MGLT: 100, Consumables: "1 week"}              // 168 hours, 16800 MGLT per leg
+	falcon := domain.Starship{Name: "Millennium Falcon", MGLT: 75, Consumables: "2 months"} // 1440 hours, 108000 MGLT per leg
+	ceiling, err := LookupStrategy(StrategyCeiling)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string          // Test case description
+		ship       domain.Starship // Starship travelling
+		distance   int64           // Distance to travel
+		dwellHours int64           // Resupply hours per stop
+		strategy   StopStrategy    // Strategy counting the stops, nil for the exact rule
+		want       Travel          // Expected travel time
+		wantErr    error           // Expected error
+	}{
+		{
+			name:     "single leg",
+			ship:     xwing,
+			distance: 5000,
+			want:     Travel{Hours: 50, Legs: 1, LegHours: 50, TotalHours: 50},
+		},
+		{
+			name:     "several legs rounded up",
+			ship:     xwing,
+			distance: 1000001,
+			want:     Travel{Hours: 10001, Legs: 60, LegHours: 168, TotalHours: 10001},
+		},
+		{
+			name:       "dwell at every stop",
+			ship:       xwing,
+			distance:   1000000,
+			dwellHours: 24,
+			want:       Travel{Hours: 10000, Legs: 60, LegHours: 168, DwellHours: 59 * 24, TotalHours: 10000 + 59*24},
+		},
+		{
+			name:     "margin legs",
+			ship:     falcon,
+			distance: 1000000,
+			strategy: MarginStrategy{Percent: 50},
+			want:     Travel{Hours: 13334, Legs: 19, LegHours: 720, TotalHours: 13334},
+		},
+		{
+			name:     "ceiling stop on arrival",
+			ship:     xwing,
+			distance: 16800,
+			strategy: ceiling,
+			want:     Travel{Hours: 168, Legs: 2, LegHours: 167, TotalHours: 168},
+		},
+		{
+			name:     "ceiling stop before running dry",
+			ship:     xwing,
+			distance: 33600,
+			strategy: ceiling,
+			want:     Travel{Hours: 336, Legs: 3, LegHours: 167, TotalHours: 336},
+		},
+		{
+			name:     "legs shorter than an hour",
+			ship:     domain.Starship{Name: "Shuttle", MGLT: 100, Consumables: "1 day"}, // 24 MGLT per leg with a 99% margin
+			distance: 240,
+			strategy: MarginStrategy{Percent: 99},
+			want:     Travel{Hours: 3, Legs: 10, LegHours: 1, TotalHours: 3},
+		},
+		{
+			name:       "dwell overflow",
+			ship:       domain.Starship{Name: "Slow", MGLT: 1, Consumables: "1 day"},
+			distance:   math.MaxInt64,
+			dwellHours: 1000,
+			wantErr:    ErrOverflow,
+		},
+		{
+			name:     "unknown speed",
+			ship:     domain.Starship{Name: "Executor", UnknownMGLT: true, Consumables: "6 years"},
+			distance: 1000,
+			wantErr:  ErrUnknownMGLT,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stops, _ := Options{Strategy: tt.strategy}.stops(tt.ship, tt.distance)
+			got, err := TravelForShip(tt.ship, tt.distance, stops, tt.dwellHours, tt.strategy)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("TravelForShip() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("TravelForShip() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}